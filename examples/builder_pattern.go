@@ -6,7 +6,7 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
@@ -78,7 +78,7 @@ func builderExample() {
 		WithName("builder-app").
 		WithVersion("2.0.0").
 		WithTimeout(45 * time.Second).
-		WithLogger(log.Default()).
+		WithLogger(slog.Default()).
 		Build()
 
 	ctx := context.Background()
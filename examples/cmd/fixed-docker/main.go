@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"time"
 
@@ -14,7 +15,7 @@ import (
 
 func main() {
 	// Test the fixed Docker transport from discovery service
-	logger := log.New(os.Stdout, "[TEST] ", log.LstdFlags)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 	disc := discovery.NewDiscovery(logger)
 
 	// Get the Docker MCP transport (which should now use direct TCP)
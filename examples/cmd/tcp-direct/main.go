@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"time"
 
@@ -20,7 +21,7 @@ func main() {
 	clientConfig := client.ClientConfig{
 		Name:    "test-client",
 		Version: "1.0.0",
-		Logger:  log.New(os.Stdout, "[TEST] ", log.LstdFlags),
+		Logger:  slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
 		Timeout: 30 * time.Second,
 	}
 
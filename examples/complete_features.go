@@ -112,7 +112,12 @@ func demonstrateResources(ctx context.Context, client *client.Client) {
 		} else {
 			fmt.Printf("Resource content (%d items):\n", len(content.Contents))
 			for i, item := range content.Contents {
-				fmt.Printf("  Item %d: Type=%s, Length=%d bytes\n", i+1, item.Type, len(item.Text))
+				if item.IsBlob() {
+					data, _ := item.DecodeBlob()
+					fmt.Printf("  Item %d: MimeType=%s, Blob=%d bytes\n", i+1, item.MimeType, len(data))
+				} else {
+					fmt.Printf("  Item %d: MimeType=%s, Text=%d bytes\n", i+1, item.MimeType, len(item.Text))
+				}
 			}
 		}
 	}
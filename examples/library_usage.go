@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
@@ -44,7 +45,7 @@ func exampleTCPClient() error {
 	config := client.ClientConfig{
 		Name:    "example-library-app",
 		Version: "1.0.0",
-		Logger:  log.Default(),
+		Logger:  slog.Default(),
 		Timeout: 30 * time.Second,
 	}
 
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// interactiveCommands lists the commands offered by TAB completion
+var interactiveCommands = []string{
+	"help", "discover", "connect", "disconnect", "list-tools", "list-resources",
+	"list-templates", "read-template", "list-prompts", "get-prompt", "call-tool",
+	"logs", "status", "exit", "quit",
+}
+
+// completionTimeout bounds completion/complete round trips so a slow server
+// never freezes the prompt
+const completionTimeout = 2 * time.Second
+
+// Do implements readline.AutoCompleter. It returns the suffixes that complete
+// the word under the cursor and the length of the text they replace.
+func (s *InteractiveSession) Do(line []rune, pos int) ([][]rune, int) {
+	input := string(line[:pos])
+
+	// Prompting for a template variable: the whole line is the value
+	if ref, argName := s.valueCompletion(); ref != nil {
+		return s.completeValue(*ref, argName, input)
+	}
+
+	words := strings.Fields(input)
+	if len(words) == 0 || !strings.HasSuffix(input, " ") && len(words) == 1 {
+		word := ""
+		if len(words) == 1 {
+			word = words[0]
+		}
+		return completePrefix(interactiveCommands, word, " ")
+	}
+
+	// The word being typed is empty when the cursor follows a space
+	current := ""
+	if !strings.HasSuffix(input, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	command := words[0]
+	if command == "logs" && len(words) == 1 {
+		levels := []string{"off"}
+		for _, level := range mcp.LoggingLevels {
+			levels = append(levels, string(level))
+		}
+		return completePrefix(levels, current, "")
+	}
+	if command != "get-prompt" && command != "gp" {
+		return nil, 0
+	}
+
+	if len(words) == 1 {
+		return completePrefix(s.promptNames(), current, " ")
+	}
+
+	prompt := s.findPrompt(words[1])
+	if prompt == nil {
+		return nil, 0
+	}
+
+	argName, partial, hasValue := strings.Cut(current, "=")
+	if !hasValue {
+		var names []string
+		for _, arg := range prompt.Arguments {
+			names = append(names, arg.Name)
+		}
+		return completePrefix(names, current, "=")
+	}
+
+	return s.completeValue(mcp.NewPromptReference(prompt.Name), argName, partial)
+}
+
+// completeValue asks the server for values of argName starting with partial
+func (s *InteractiveSession) completeValue(ref mcp.CompletionReference, argName, partial string) ([][]rune, int) {
+	mcpClient := s.completionClient()
+	if mcpClient == nil {
+		return nil, 0
+	}
+	if caps := mcpClient.GetServerCapabilities(); caps == nil || caps.Completions == nil {
+		return nil, 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	// Completion is best effort; errors would only garble the prompt
+	completion, err := mcpClient.Complete(ctx, ref, argName, partial)
+	if err != nil {
+		return nil, 0
+	}
+
+	return completePrefix(completion.Values, partial, " ")
+}
+
+// promptNames returns the cached prompt names, fetching them on first use
+func (s *InteractiveSession) promptNames() []string {
+	prompts := s.cachedPrompts()
+	names := make([]string, 0, len(prompts))
+	for _, prompt := range prompts {
+		names = append(names, prompt.Name)
+	}
+	return names
+}
+
+// findPrompt looks up a prompt by name in the cached prompt list
+func (s *InteractiveSession) findPrompt(name string) *mcp.Prompt {
+	prompts := s.cachedPrompts()
+	for i := range prompts {
+		if prompts[i].Name == name {
+			return &prompts[i]
+		}
+	}
+	return nil
+}
+
+// cachedPrompts returns the prompts of the current server, fetching them on
+// first use
+func (s *InteractiveSession) cachedPrompts() []mcp.Prompt {
+	s.completionMu.Lock()
+	mcpClient, prompts := s.currentClient, s.prompts
+	s.completionMu.Unlock()
+
+	if prompts == nil && mcpClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+		if fetched, err := mcpClient.ListPrompts(ctx); err == nil {
+			prompts = fetched
+			s.completionMu.Lock()
+			// Keep the list only if the session still uses the same server
+			if s.currentClient == mcpClient {
+				s.prompts = prompts
+			}
+			s.completionMu.Unlock()
+		}
+	}
+	return prompts
+}
+
+// setClient makes mcpClient the current client, dropping the prompts cached
+// for the previous one
+func (s *InteractiveSession) setClient(mcpClient *client.Client) {
+	s.completionMu.Lock()
+	defer s.completionMu.Unlock()
+	s.currentClient = mcpClient
+	s.prompts = nil
+}
+
+// setPrompts caches the prompt list of the current server
+func (s *InteractiveSession) setPrompts(prompts []mcp.Prompt) {
+	s.completionMu.Lock()
+	defer s.completionMu.Unlock()
+	s.prompts = prompts
+}
+
+// completionClient returns the client used for completion/complete
+func (s *InteractiveSession) completionClient() *client.Client {
+	s.completionMu.Lock()
+	defer s.completionMu.Unlock()
+	return s.currentClient
+}
+
+// setValueCompletion makes TAB complete values of argName for ref, or
+// commands again if ref is nil
+func (s *InteractiveSession) setValueCompletion(ref *mcp.CompletionReference, argName string) {
+	s.completionMu.Lock()
+	defer s.completionMu.Unlock()
+	s.completionRef = ref
+	s.completionArg = argName
+}
+
+// valueCompletion returns the reference and argument set by
+// setValueCompletion
+func (s *InteractiveSession) valueCompletion() (*mcp.CompletionReference, string) {
+	s.completionMu.Lock()
+	defer s.completionMu.Unlock()
+	return s.completionRef, s.completionArg
+}
+
+// completePrefix returns the remainder of every candidate that starts with
+// prefix, followed by suffix
+func completePrefix(candidates []string, prefix, suffix string) ([][]rune, int) {
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	var matches [][]rune
+	for _, candidate := range sorted {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, []rune(candidate[len(prefix):]+suffix))
+		}
+	}
+	return matches, len([]rune(prefix))
+}
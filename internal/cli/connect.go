@@ -3,7 +3,6 @@ package cli
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"strings"
 	"time"
@@ -22,6 +21,7 @@ var (
 	connectArgs    []string
 	connectType    string
 	connectTimeout time.Duration
+	connectRoots   []string
 )
 
 // connectCmd represents the connect command
@@ -57,13 +57,11 @@ func init() {
 	connectCmd.Flags().StringVar(&connectCommand, "command", "", "Command to execute for STDIO transport")
 	connectCmd.Flags().StringSliceVar(&connectArgs, "args", []string{}, "Arguments for the command")
 	connectCmd.Flags().DurationVar(&connectTimeout, "timeout", 30*time.Second, "Connection timeout")
+	connectCmd.Flags().StringSliceVar(&connectRoots, "root", []string{}, "Directory or URI to expose to the server as a root (repeatable)")
 }
 
 func runConnect(cmd *cobra.Command, args []string) {
-	logger := log.New(os.Stdout, "", 0)
-	if verbose {
-		logger = log.New(os.Stdout, "[MCP] ", log.LstdFlags)
-	}
+	logger := newLogger("connect")
 
 	// Determine transport type from flags
 	tcpFlag, _ := cmd.Flags().GetBool("tcp")
@@ -114,14 +112,24 @@ func runConnect(cmd *cobra.Command, args []string) {
 
 	// Create client
 	clientConfig := client.ClientConfig{
-		Name:    "mcp-client-go",
-		Version: "1.0.0",
-		Logger:  logger,
-		Timeout: connectTimeout,
+		Name:       "mcp-client-go",
+		Version:    "1.0.0",
+		Logger:     logger,
+		Timeout:    connectTimeout,
+		Middleware: verboseMiddleware(logger),
 	}
 
 	mcpClient := client.NewClient(mcpTransport, clientConfig)
 
+	if len(connectRoots) > 0 {
+		roots, err := rootsFromPaths(connectRoots)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		mcpClient.SetRoots(roots)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
@@ -149,6 +157,7 @@ func runConnect(cmd *cobra.Command, args []string) {
 	if serverInfo := mcpClient.GetServerInfo(); serverInfo != nil {
 		fmt.Printf("🚀 Server: %s %s\n", serverInfo.Name, serverInfo.Version)
 	}
+	fmt.Printf("🤝 Protocol version: %s\n", mcpClient.ProtocolVersion())
 
 	// List available tools
 	fmt.Println("\n📋 Listing available tools...")
@@ -162,9 +171,7 @@ func runConnect(cmd *cobra.Command, args []string) {
 			fmt.Printf("📝 Available tools (%d):\n", len(tools))
 			for i, tool := range tools {
 				fmt.Printf("  %d. %s\n", i+1, tool.Name)
-				if tool.Description != "" {
-					fmt.Printf("     Description: %s\n", tool.Description)
-				}
+				printToolDetails(tool)
 			}
 		}
 	}
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// printContent prints a single item of tool result or prompt content
+func printContent(content mcp.Content) {
+	if text, ok := content.AsText(); ok {
+		fmt.Println(text.Text)
+		return
+	}
+
+	if image, ok := content.AsImage(); ok {
+		fmt.Printf("🖼️  Image (%s, %s)\n", image.MimeType, formatBlobSize(content))
+		return
+	}
+
+	if audio, ok := content.AsAudio(); ok {
+		fmt.Printf("🔊 Audio (%s, %s)\n", audio.MimeType, formatBlobSize(content))
+		return
+	}
+
+	if embedded, ok := content.AsEmbeddedResource(); ok {
+		fmt.Printf("📄 Embedded resource: %s\n", embedded.Resource.URI)
+		printResourceContents(embedded.Resource)
+		return
+	}
+
+	if link, ok := content.AsResourceLink(); ok {
+		fmt.Printf("🔗 Resource link: %s\n", link.URI)
+		if link.Name != "" {
+			fmt.Printf("   Name: %s\n", link.Name)
+		}
+		if link.Description != "" {
+			fmt.Printf("   Description: %s\n", link.Description)
+		}
+		if link.MimeType != "" {
+			fmt.Printf("   MIME type: %s\n", link.MimeType)
+		}
+		if link.Size > 0 {
+			fmt.Printf("   Size: %s\n", formatBytes(link.Size))
+		}
+		return
+	}
+
+	fmt.Printf("Content type: %s\n", content.Type)
+}
+
+// printResourceContents prints the text of a resource, or a summary of its
+// binary data
+func printResourceContents(contents mcp.ResourceContents) {
+	if !contents.IsBlob() {
+		fmt.Println(contents.Text)
+		return
+	}
+
+	mimeType := contents.MimeType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	data, err := contents.DecodeBlob()
+	if err != nil {
+		fmt.Printf("Binary data (%s, invalid base64: %v)\n", mimeType, err)
+		return
+	}
+	fmt.Printf("Binary data (%s, %s)\n", mimeType, formatBytes(int64(len(data))))
+}
+
+// formatBlobSize describes the decoded size of image or audio content
+func formatBlobSize(content mcp.Content) string {
+	data, err := content.DecodeBlob()
+	if err != nil {
+		return "invalid base64"
+	}
+	return formatBytes(int64(len(data)))
+}
+
+// formatBytes formats a byte count for humans
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// printStructuredContent prints a tool's structured result as indented JSON
+func printStructuredContent(structured map[string]interface{}) {
+	data, err := json.MarshalIndent(structured, "", "  ")
+	if err != nil {
+		fmt.Printf("Invalid structured content: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// toolHints summarizes a tool's annotations, e.g. "read-only, idempotent".
+// It returns "" for tools without annotations.
+func toolHints(tool mcp.Tool) string {
+	annotations := tool.Annotations
+	if annotations == nil {
+		return ""
+	}
+
+	var hints []string
+	if annotations.IsReadOnly() {
+		hints = append(hints, "read-only")
+	} else if annotations.IsDestructive() {
+		hints = append(hints, "destructive")
+	}
+	if annotations.IsIdempotent() {
+		hints = append(hints, "idempotent")
+	}
+	if annotations.IsOpenWorld() {
+		hints = append(hints, "open-world")
+	}
+	return strings.Join(hints, ", ")
+}
+
+// printToolDetails prints the title, description and hints of a listed tool
+func printToolDetails(tool mcp.Tool) {
+	if title := tool.DisplayName(); title != tool.Name {
+		fmt.Printf("     Title: %s\n", title)
+	}
+	if tool.Description != "" {
+		fmt.Printf("     Description: %s\n", tool.Description)
+	}
+	if hints := toolHints(tool); hints != "" {
+		fmt.Printf("     Hints: %s\n", hints)
+	}
+	if tool.OutputSchema != nil {
+		fmt.Println("     Returns structured output")
+	}
+}
+
+// formatCatalogDiff summarizes a tool list change, e.g.
+// "🔔 Tools changed: +search, -grep, ~fetch"
+func formatCatalogDiff(diff client.CatalogDiff) string {
+	var changes []string
+	for _, tool := range diff.Added {
+		changes = append(changes, "+"+tool.Name)
+	}
+	for _, tool := range diff.Removed {
+		changes = append(changes, "-"+tool.Name)
+	}
+	for _, tool := range diff.Changed {
+		changes = append(changes, "~"+tool.Name)
+	}
+	return "🔔 Tools changed: " + strings.Join(changes, ", ")
+}
+
+// printArgumentErrors explains why tool arguments were rejected before the
+// call was sent. It returns false if err is not such an error.
+func printArgumentErrors(toolName string, err error) bool {
+	var invalid *client.ValidationError
+	if errors.As(err, &invalid) {
+		fmt.Printf("❌ Invalid arguments for tool %s:\n", toolName)
+		for _, violation := range invalid.Violations {
+			path := violation.Path
+			if path == "" {
+				path = "(arguments)"
+			}
+			fmt.Printf("   • %s: %s\n", path, violation.Message)
+		}
+		return true
+	}
+
+	if errors.Is(err, client.ErrUnknownTool) {
+		fmt.Printf("❌ The server has no tool named %s\n", toolName)
+		return true
+	}
+	return false
+}
+
+// printMetricsSummary prints a table of the requests made during the run
+func printMetricsSummary(snapshot client.MetricsSnapshot) {
+	fmt.Println("\n📊 Request statistics:")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "   METHOD\tCALLS\tERRORS\tAVG\tMAX")
+	for _, method := range snapshot.Methods {
+		fmt.Fprintf(w, "   %s\t%d\t%d\t%v\t%v\n", method.Method, method.Requests, method.Errors(),
+			method.Latency.Mean().Round(time.Microsecond), method.Latency.Max.Round(time.Microsecond))
+	}
+	w.Flush()
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"time"
 
@@ -53,10 +52,7 @@ func init() {
 }
 
 func runDiscover(cmd *cobra.Command, args []string) {
-	logger := log.New(os.Stdout, "", 0)
-	if verbose {
-		logger = log.New(os.Stdout, "[DISCOVERY] ", log.LstdFlags)
-	}
+	logger := newLogger("discovery")
 
 	discoveryService := discovery.NewDiscovery(logger)
 	discoveryService.SetTimeout(discoveryTimeout)
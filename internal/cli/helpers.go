@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
+
+	"github.com/spf13/cobra"
+)
+
+// connectionFlags holds the transport flags shared by commands that talk to a
+// single server
+type connectionFlags struct {
+	transportType string
+	host          string
+	port          int
+	command       string
+	args          []string
+}
+
+// register adds the connection flags to cmd
+func (f *connectionFlags) register(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&f.transportType, "type", "tcp", "Connection type: tcp, stdio, or docker")
+	flags.BoolP("tcp", "t", false, "Use TCP transport")
+	flags.BoolP("stdio", "s", false, "Use STDIO transport")
+	flags.BoolP("docker", "d", false, "Use Docker transport (alpine/socat)")
+
+	flags.StringVar(&f.host, "host", "localhost", "TCP host to connect to")
+	flags.IntVar(&f.port, "port", 8811, "TCP port to connect to")
+	flags.StringVar(&f.command, "command", "", "Command to execute for STDIO transport")
+	flags.StringSliceVar(&f.args, "args", []string{}, "Arguments for the command")
+}
+
+// newTransport creates the transport selected by the flags on cmd
+func (f *connectionFlags) newTransport(cmd *cobra.Command) (transport.Transport, error) {
+	tcpFlag, _ := cmd.Flags().GetBool("tcp")
+	stdioFlag, _ := cmd.Flags().GetBool("stdio")
+	dockerFlag, _ := cmd.Flags().GetBool("docker")
+
+	transportType := f.transportType
+	if tcpFlag {
+		transportType = "tcp"
+	} else if stdioFlag {
+		transportType = "stdio"
+	} else if dockerFlag {
+		transportType = "docker"
+	}
+
+	fmt.Printf("🔌 Connecting to MCP server using %s transport...\n", transportType)
+
+	switch transportType {
+	case "tcp":
+		fmt.Printf("   Host: %s:%d\n", f.host, f.port)
+		return transport.NewTCPTransport(f.host, f.port), nil
+
+	case "stdio":
+		if f.command == "" {
+			return nil, fmt.Errorf("STDIO transport requires --command flag")
+		}
+		fmt.Printf("   Command: %s %s\n", f.command, strings.Join(f.args, " "))
+		return transport.NewStdioTransport(f.command, f.args), nil
+
+	case "docker":
+		fmt.Println("   Using Docker alpine/socat -> host.docker.internal:8811")
+		return transport.NewStdioTransport("docker", []string{
+			"run", "-i", "--rm", "alpine/socat",
+			"STDIO", "TCP:host.docker.internal:8811",
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported transport type: %s", transportType)
+	}
+}
+
+// rootsFromPaths converts --root flag values into MCP roots. Plain paths are
+// made absolute and turned into file:// URIs; values that already look like
+// URIs are passed through unchanged.
+func rootsFromPaths(paths []string) ([]mcp.Root, error) {
+	roots := make([]mcp.Root, 0, len(paths))
+	for _, path := range paths {
+		if strings.Contains(path, "://") {
+			roots = append(roots, mcp.Root{URI: path})
+			continue
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root %q: %w", path, err)
+		}
+
+		uri := url.URL{Scheme: "file", Path: filepath.ToSlash(absPath)}
+		roots = append(roots, mcp.Root{URI: uri.String(), Name: filepath.Base(absPath)})
+	}
+	return roots, nil
+}
+
+// serverLogLevelUsage is the help text shared by --server-log-level flags
+const serverLogLevelUsage = "Stream server log messages at or above this level: debug, info, notice, warning, error, critical, alert or emergency"
+
+// startServerLogs prints server log messages through print and asks the
+// server to send messages at or above levelName
+func startServerLogs(ctx context.Context, mcpClient *client.Client, levelName string, print func(string)) error {
+	level, err := mcp.ParseLoggingLevel(levelName)
+	if err != nil {
+		return err
+	}
+
+	mcpClient.SetLogSink(client.LogSinkFunc(func(message mcp.LoggingMessageNotification) {
+		print("📜 " + client.FormatLogMessage(message))
+	}))
+
+	return mcpClient.SetLogLevel(ctx, level)
+}
+
+// progressBar renders server progress notifications on a single terminal line
+type progressBar struct {
+	mu     sync.Mutex
+	out    io.Writer
+	width  int
+	active bool
+}
+
+func newProgressBar() *progressBar {
+	return &progressBar{out: os.Stdout, width: 30}
+}
+
+// update redraws the bar for a progress notification
+func (b *progressBar) update(p mcp.ProgressNotification) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var line string
+	if p.Total > 0 {
+		// Servers may report progress past the total or below zero
+		ratio := p.Progress / p.Total
+		if ratio > 1 {
+			ratio = 1
+		} else if ratio < 0 {
+			ratio = 0
+		}
+		filled := int(ratio * float64(b.width))
+		line = fmt.Sprintf("⏳ [%s%s] %3.0f%%", strings.Repeat("█", filled), strings.Repeat("░", b.width-filled), ratio*100)
+	} else {
+		line = fmt.Sprintf("⏳ Progress: %g", p.Progress)
+	}
+	if p.Message != "" {
+		line += " " + p.Message
+	}
+
+	// Clear the rest of the previous line
+	fmt.Fprintf(b.out, "\r%s\033[K", line)
+	b.active = true
+}
+
+// println prints a line above the bar; the bar is redrawn on the next update
+func (b *progressBar) println(line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.active {
+		fmt.Fprint(b.out, "\r\033[K")
+		b.active = false
+	}
+	fmt.Fprintln(b.out, line)
+}
+
+// finish moves past the bar once the request completes
+func (b *progressBar) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.active {
+		fmt.Fprintln(b.out)
+		b.active = false
+	}
+}
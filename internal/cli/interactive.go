@@ -1,21 +1,24 @@
 package cli
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/discovery"
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/uritemplate"
 
+	"github.com/chzyer/readline"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -36,7 +39,12 @@ and interacting with MCP servers. Available commands:
   disconnect              - Disconnect from current server
   list-tools              - List tools available on current server
   list-resources          - List resources available on current server
+  list-templates          - List resource templates available on current server
+  read-template <n>       - Read a resource template, prompting for each variable
+  list-prompts            - List prompts available on current server
+  get-prompt <name> [k=v] - Get a prompt with optional arguments
   call-tool <name> [args] - Execute a tool with optional JSON arguments
+  logs [level|off]        - Stream server log messages at or above level
   status                  - Show connection status
   exit/quit               - Exit interactive mode
 
@@ -45,21 +53,51 @@ Examples:
   > connect 1
   > list-tools
   > call-tool search {"query": "golang"}
-  > exit`,
+  > get-prompt review branch=main
+  > logs debug
+  > exit
+
+Press TAB to complete commands, prompt names, prompt arguments and resource
+template variables. Argument values are completed by the server when it
+supports completion/complete.`,
 	Run: runInteractive,
 }
 
+var (
+	interactiveRoots    []string
+	interactiveLogLevel string
+)
+
 func init() {
 	rootCmd.AddCommand(interactiveCmd)
+
+	interactiveCmd.Flags().StringSliceVar(&interactiveRoots, "root", []string{}, "Directory or URI to expose to connected servers as a root (repeatable)")
+	interactiveCmd.Flags().StringVar(&interactiveLogLevel, "server-log-level", "", serverLogLevelUsage)
 }
 
 type InteractiveSession struct {
-	logger           *log.Logger
+	logger           *slog.Logger
 	discoveryService *discovery.Discovery
 	availableServers []discovery.ServerInfo
 	currentClient    *client.Client
 	currentServer    string
-	reader           *bufio.Reader
+	readline         *readline.Instance
+	templates        []mcp.ResourceTemplate
+	prompts          []mcp.Prompt
+
+	// progress renders tool progress; server log lines are printed above it
+	progress *progressBar
+	// logLevel is the server log level streamed to the terminal, "" when off
+	logLevel string
+
+	// completionMu guards the fields read by the readline completer, which
+	// runs on readline's goroutine: currentClient, prompts, completionRef and
+	// completionArg. The command goroutine holds it while writing them.
+	completionMu sync.Mutex
+	// completionRef is set while prompting for a template variable so TAB
+	// completes its value
+	completionRef *mcp.CompletionReference
+	completionArg string
 
 	// Colors for output
 	promptColor  *color.Color
@@ -70,18 +108,39 @@ type InteractiveSession struct {
 
 func runInteractive(cmd *cobra.Command, args []string) {
 	session := &InteractiveSession{
-		logger:           log.New(os.Stdout, "", 0),
+		logger:           newLogger("interactive"),
 		discoveryService: discovery.NewDiscovery(nil),
-		reader:           bufio.NewReader(os.Stdin),
 		promptColor:      color.New(color.FgCyan, color.Bold),
 		successColor:     color.New(color.FgGreen),
 		errorColor:       color.New(color.FgRed),
 		infoColor:        color.New(color.FgBlue),
 	}
 
-	if verbose {
-		session.logger = log.New(os.Stdout, "[INTERACTIVE] ", log.LstdFlags)
+	if interactiveLogLevel != "" {
+		if _, err := mcp.ParseLoggingLevel(interactiveLogLevel); err != nil {
+			session.errorColor.Printf("❌ Invalid --server-log-level: %v\n", err)
+			os.Exit(1)
+		}
+		session.logLevel = interactiveLogLevel
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          session.promptColor.Sprint("mcp-client> "),
+		AutoComplete:    session,
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		session.errorColor.Printf("❌ Failed to initialize terminal: %v\n", err)
+		os.Exit(1)
 	}
+	defer rl.Close()
+	session.readline = rl
+
+	// Log lines arriving while the prompt is shown must go through readline
+	// so the prompt is redrawn below them
+	session.progress = newProgressBar()
+	session.progress.out = rl.Stdout()
 
 	session.start()
 }
@@ -94,8 +153,12 @@ func (s *InteractiveSession) start() {
 	s.discoverServers()
 	// Main command loop
 	for {
-		s.promptColor.Print("\nmcp-client> ")
-		input, err := s.reader.ReadString('\n')
+		fmt.Println()
+		input, err := s.readline.Readline()
+		if err == readline.ErrInterrupt {
+			// Ctrl+C clears the current line
+			continue
+		}
 		if err != nil {
 			// Handle EOF (Ctrl+D) and other input errors gracefully
 			if err == io.EOF {
@@ -131,8 +194,18 @@ func (s *InteractiveSession) start() {
 			s.listTools()
 		case "list-resources", "lr":
 			s.listResources()
+		case "list-templates", "ltp":
+			s.listTemplates()
+		case "read-template", "rt":
+			s.readTemplate(args)
+		case "list-prompts", "lp":
+			s.listPrompts()
+		case "get-prompt", "gp":
+			s.getPrompt(args)
 		case "call-tool", "ct":
 			s.callTool(args)
+		case "logs":
+			s.logs(args)
 		case "status", "s":
 			s.showStatus()
 		case "exit", "quit", "q":
@@ -152,9 +225,15 @@ func (s *InteractiveSession) showHelp() {
 	fmt.Println("  disconnect        - Disconnect from current server")
 	fmt.Println("  list-tools        - List tools available on current server")
 	fmt.Println("  list-resources    - List resources available on current server")
+	fmt.Println("  list-templates    - List resource templates available on current server")
+	fmt.Println("  read-template <n> - Read a resource template, prompting for each variable")
+	fmt.Println("  list-prompts      - List prompts available on current server")
+	fmt.Println("  get-prompt <n> [k=v] - Get a prompt with optional arguments")
 	fmt.Println("  call-tool <n> [args] - Call a tool with optional JSON arguments")
+	fmt.Println("  logs [level|off]  - Stream server log messages at or above level")
 	fmt.Println("  status            - Show connection status")
 	fmt.Println("  exit/quit         - Exit the client")
+	fmt.Println("\nPress TAB to complete commands, prompt arguments and template variables.")
 }
 
 func (s *InteractiveSession) discoverServers() {
@@ -225,21 +304,37 @@ func (s *InteractiveSession) connectToServer(args []string) {
 
 	// Create client
 	clientConfig := client.ClientConfig{
-		Name:    "mcp-client-go",
-		Version: "1.0.0",
-		Logger:  s.logger,
-		Timeout: 30 * time.Second,
+		Name:              "mcp-client-go",
+		Version:           "1.0.0",
+		Logger:            s.logger,
+		Timeout:           30 * time.Second,
+		CacheCatalog:      true,
+		ValidateArguments: true,
+		Middleware:        verboseMiddleware(s.logger),
+	}
+
+	s.setClient(client.NewClient(selectedServer.Transport, clientConfig))
+	s.currentClient.OnCatalogChanged(func(diff client.CatalogDiff) {
+		s.progress.println(formatCatalogDiff(diff))
+	})
+
+	if len(interactiveRoots) > 0 {
+		roots, err := rootsFromPaths(interactiveRoots)
+		if err != nil {
+			s.errorColor.Printf("❌ %v\n", err)
+			s.setClient(nil)
+			return
+		}
+		s.currentClient.SetRoots(roots)
 	}
 
-	s.currentClient = client.NewClient(selectedServer.Transport, clientConfig)
-
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	// Connect
 	if err := s.currentClient.Connect(ctx); err != nil {
 		s.errorColor.Printf("❌ Failed to connect: %v\n", err)
-		s.currentClient = nil
+		s.setClient(nil)
 		return
 	}
 
@@ -252,7 +347,7 @@ func (s *InteractiveSession) connectToServer(args []string) {
 	if err := s.currentClient.Initialize(ctx, clientInfo); err != nil {
 		s.errorColor.Printf("❌ Failed to initialize MCP protocol: %v\n", err)
 		s.currentClient.Disconnect()
-		s.currentClient = nil
+		s.setClient(nil)
 		return
 	}
 
@@ -263,6 +358,13 @@ func (s *InteractiveSession) connectToServer(args []string) {
 	if serverInfo := s.currentClient.GetServerInfo(); serverInfo != nil {
 		s.infoColor.Printf("🚀 Server: %s %s\n", serverInfo.Name, serverInfo.Version)
 	}
+	s.infoColor.Printf("🤝 Protocol version: %s\n", s.currentClient.ProtocolVersion())
+
+	if s.logLevel != "" {
+		if err := startServerLogs(ctx, s.currentClient, s.logLevel, s.progress.println); err != nil {
+			s.errorColor.Printf("⚠️  Server logs unavailable: %v\n", err)
+		}
+	}
 }
 
 func (s *InteractiveSession) disconnectFromServer() {
@@ -277,8 +379,9 @@ func (s *InteractiveSession) disconnectFromServer() {
 		s.successColor.Printf("🔌 Disconnected from %s\n", s.currentServer)
 	}
 
-	s.currentClient = nil
+	s.setClient(nil)
 	s.currentServer = ""
+	s.templates = nil
 }
 
 func (s *InteractiveSession) listTools() {
@@ -304,9 +407,7 @@ func (s *InteractiveSession) listTools() {
 	s.successColor.Printf("📝 Available tools (%d):\n", len(tools))
 	for i, tool := range tools {
 		fmt.Printf("  %d. %s\n", i+1, tool.Name)
-		if tool.Description != "" {
-			fmt.Printf("     Description: %s\n", tool.Description)
-		}
+		printToolDetails(tool)
 	}
 }
 
@@ -342,6 +443,209 @@ func (s *InteractiveSession) listResources() {
 	}
 }
 
+func (s *InteractiveSession) listTemplates() {
+	if s.currentClient == nil {
+		s.errorColor.Println("❌ No active connection. Use 'connect' first.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	templates, err := s.currentClient.ListResourceTemplates(ctx)
+	if err != nil {
+		s.errorColor.Printf("❌ Failed to list resource templates: %v\n", err)
+		return
+	}
+	s.templates = templates
+
+	if len(templates) == 0 {
+		s.infoColor.Println("🧩 No resource templates available")
+		return
+	}
+
+	s.successColor.Printf("🧩 Available resource templates (%d):\n", len(templates))
+	printResourceTemplates(templates)
+}
+
+func (s *InteractiveSession) readTemplate(args []string) {
+	if s.currentClient == nil {
+		s.errorColor.Println("❌ No active connection. Use 'connect' first.")
+		return
+	}
+
+	if len(args) == 0 {
+		s.errorColor.Println("❌ Please specify a template name or index")
+		return
+	}
+
+	if s.templates == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		templates, err := s.currentClient.ListResourceTemplates(ctx)
+		cancel()
+		if err != nil {
+			s.errorColor.Printf("❌ Failed to list resource templates: %v\n", err)
+			return
+		}
+		s.templates = templates
+	}
+
+	// Select the template by index or name
+	var selected *mcp.ResourceTemplate
+	if index, err := strconv.Atoi(args[0]); err == nil {
+		if index > 0 && index <= len(s.templates) {
+			selected = &s.templates[index-1]
+		}
+	} else {
+		for i := range s.templates {
+			if s.templates[i].Name == args[0] {
+				selected = &s.templates[i]
+				break
+			}
+		}
+	}
+
+	if selected == nil {
+		s.errorColor.Println("❌ Template not found. Use 'list-templates' to see available templates.")
+		return
+	}
+
+	variables, err := uritemplate.Variables(selected.URITemplate)
+	if err != nil {
+		s.errorColor.Printf("❌ Invalid URI template: %v\n", err)
+		return
+	}
+
+	s.infoColor.Printf("🧩 %s\n", selected.URITemplate)
+	ref := mcp.NewResourceReference(selected.URITemplate)
+	vars := make(map[string]interface{})
+	for _, name := range variables {
+		value, err := s.readValue(fmt.Sprintf("  %s: ", name), &ref, name)
+		if err != nil {
+			s.errorColor.Printf("❌ Error reading input: %v\n", err)
+			return
+		}
+		if value != "" {
+			vars[name] = value
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.currentClient.ReadResourceTemplate(ctx, selected.URITemplate, vars)
+	if err != nil {
+		s.errorColor.Printf("❌ Failed to read resource: %v\n", err)
+		return
+	}
+
+	s.successColor.Printf("📄 Resource contents (%d):\n", len(result.Contents))
+	for _, content := range result.Contents {
+		if content.URI != "" {
+			s.infoColor.Printf("--- %s ---\n", content.URI)
+		}
+		printResourceContents(content)
+	}
+}
+
+// readValue prompts for a single value. While it is being typed, TAB
+// completes it through completion/complete for the given reference.
+func (s *InteractiveSession) readValue(prompt string, ref *mcp.CompletionReference, argName string) (string, error) {
+	s.setValueCompletion(ref, argName)
+	s.readline.SetPrompt(s.promptColor.Sprint(prompt))
+	defer func() {
+		s.setValueCompletion(nil, "")
+		s.readline.SetPrompt(s.promptColor.Sprint("mcp-client> "))
+	}()
+
+	value, err := s.readline.Readline()
+	return strings.TrimSpace(value), err
+}
+
+func (s *InteractiveSession) listPrompts() {
+	if s.currentClient == nil {
+		s.errorColor.Println("❌ No active connection. Use 'connect' first.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	prompts, err := s.currentClient.ListPrompts(ctx)
+	if err != nil {
+		s.errorColor.Printf("❌ Failed to list prompts: %v\n", err)
+		return
+	}
+	s.setPrompts(prompts)
+
+	if len(prompts) == 0 {
+		s.infoColor.Println("💬 No prompts available")
+		return
+	}
+
+	s.successColor.Printf("💬 Available prompts (%d):\n", len(prompts))
+	for i, prompt := range prompts {
+		fmt.Printf("  %d. %s\n", i+1, prompt.Name)
+		if prompt.Description != "" {
+			fmt.Printf("     Description: %s\n", prompt.Description)
+		}
+		for _, arg := range prompt.Arguments {
+			required := ""
+			if arg.Required {
+				required = " (required)"
+			}
+			fmt.Printf("     - %s%s", arg.Name, required)
+			if arg.Description != "" {
+				fmt.Printf(": %s", arg.Description)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func (s *InteractiveSession) getPrompt(args []string) {
+	if s.currentClient == nil {
+		s.errorColor.Println("❌ No active connection. Use 'connect' first.")
+		return
+	}
+
+	if len(args) == 0 {
+		s.errorColor.Println("❌ Please specify a prompt name")
+		return
+	}
+
+	promptName := args[0]
+	arguments := make(map[string]interface{})
+	for _, arg := range args[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			s.errorColor.Printf("❌ Invalid argument %q, expected name=value\n", arg)
+			return
+		}
+		arguments[key] = value
+	}
+
+	s.infoColor.Printf("💬 Getting prompt: %s\n", promptName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.currentClient.GetPrompt(ctx, promptName, arguments)
+	if err != nil {
+		s.errorColor.Printf("❌ Failed to get prompt: %v\n", err)
+		return
+	}
+
+	if result.Description != "" {
+		s.infoColor.Println(result.Description)
+	}
+	s.successColor.Printf("📤 Prompt messages (%d):\n", len(result.Messages))
+	for _, message := range result.Messages {
+		s.infoColor.Printf("[%s] ", message.Role)
+		printContent(message.Content)
+	}
+}
+
 func (s *InteractiveSession) callTool(args []string) {
 	if s.currentClient == nil {
 		s.errorColor.Println("❌ No active connection. Use 'connect' first.")
@@ -370,8 +674,12 @@ func (s *InteractiveSession) callTool(args []string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	result, err := s.currentClient.CallTool(ctx, toolName, arguments)
+	result, err := s.currentClient.CallToolWithProgress(ctx, toolName, arguments, s.progress.update)
+	s.progress.finish()
 	if err != nil {
+		if printArgumentErrors(toolName, err) {
+			return
+		}
 		s.errorColor.Printf("❌ Tool execution failed: %v\n", err)
 		return
 	}
@@ -382,16 +690,59 @@ func (s *InteractiveSession) callTool(args []string) {
 	}
 
 	for _, content := range result.Content {
-		switch content.Type {
-		case "text":
-			fmt.Println(content.Text)
-		default:
-			fmt.Printf("Content type: %s\n", content.Type)
-			if content.Data != "" {
-				fmt.Printf("Data: %s\n", content.Data)
+		printContent(content)
+	}
+	if result.StructuredContent != nil {
+		s.infoColor.Println("🧱 Structured content:")
+		printStructuredContent(result.StructuredContent)
+	}
+}
+
+// logs shows, changes or turns off streaming of server log messages
+func (s *InteractiveSession) logs(args []string) {
+	if len(args) == 0 {
+		if s.logLevel == "" {
+			s.infoColor.Println("📜 Server logs: off")
+		} else {
+			s.infoColor.Printf("📜 Server logs: %s and above\n", s.logLevel)
+		}
+		return
+	}
+
+	if args[0] == "off" {
+		s.logLevel = ""
+		if s.currentClient != nil {
+			s.currentClient.SetLogSink(nil)
+
+			// MCP has no way to stop log messages, so ask only for the most
+			// severe ones; those are hidden by removing the sink
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			if err := s.currentClient.SetLogLevel(ctx, mcp.LoggingLevelEmergency); err != nil && !errors.Is(err, client.ErrNotSupported) {
+				s.errorColor.Printf("⚠️  Failed to lower server log level: %v\n", err)
 			}
 		}
+		s.successColor.Println("📜 Server logs turned off")
+		return
 	}
+
+	if _, err := mcp.ParseLoggingLevel(args[0]); err != nil {
+		s.errorColor.Printf("❌ %v\n", err)
+		return
+	}
+
+	if s.currentClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := startServerLogs(ctx, s.currentClient, args[0], s.progress.println); err != nil {
+			s.errorColor.Printf("❌ Failed to set server log level: %v\n", err)
+			return
+		}
+	}
+
+	s.logLevel = args[0]
+	s.successColor.Printf("📜 Streaming server logs at %s and above\n", s.logLevel)
 }
 
 func (s *InteractiveSession) showStatus() {
@@ -403,6 +754,10 @@ func (s *InteractiveSession) showStatus() {
 		if serverInfo := s.currentClient.GetServerInfo(); serverInfo != nil {
 			fmt.Printf("  Server info: %s %s\n", serverInfo.Name, serverInfo.Version)
 		}
+		fmt.Printf("  Protocol version: %s\n", s.currentClient.ProtocolVersion())
+		if s.logLevel != "" {
+			fmt.Printf("  Server logs: %s and above\n", s.logLevel)
+		}
 	} else {
 		fmt.Println("  Current connection: None ❌")
 	}
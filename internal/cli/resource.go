@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/uritemplate"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	resourceConn      connectionFlags
+	resourceTimeout   time.Duration
+	resourceLogLevel  string
+	resourceReconnect bool
+)
+
+// resourceCmd groups the resource subcommands
+var resourceCmd = &cobra.Command{
+	Use:   "resource",
+	Short: "Work with resources on an MCP server",
+	Long: `Work with resources exposed by an MCP server.
+
+Examples:
+  mcp-client resource templates --tcp --host localhost --port 8811
+  mcp-client resource watch file:///var/log/app.log --tcp --host localhost --port 8811
+  mcp-client resource watch file:///var/log/app.log --server-log-level debug
+  mcp-client resource watch file:///var/log/app.log --reconnect`,
+}
+
+// resourceTemplatesCmd represents the resource templates command
+var resourceTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List resource templates available on the server",
+	Long: `List the parameterized resources (resources/templates/list) exposed by
+an MCP server, together with the variables each URI template expects.`,
+	Args: cobra.NoArgs,
+	Run:  runResourceTemplates,
+}
+
+// resourceWatchCmd represents the resource watch command
+var resourceWatchCmd = &cobra.Command{
+	Use:   "watch <uri>",
+	Short: "Print a resource every time the server reports a change",
+	Long: `Subscribe to a resource and print its contents each time the server
+sends notifications/resources/updated. Press Ctrl-C to stop watching.
+
+The server must advertise the resources.subscribe capability. With
+--reconnect the watch survives server restarts: the client reconnects with
+backoff and subscribes again.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runResourceWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(resourceCmd)
+	resourceCmd.AddCommand(resourceWatchCmd)
+	resourceCmd.AddCommand(resourceTemplatesCmd)
+
+	resourceConn.register(resourceCmd)
+	resourceCmd.PersistentFlags().DurationVar(&resourceTimeout, "timeout", 30*time.Second, "Connection timeout")
+	resourceCmd.PersistentFlags().StringVar(&resourceLogLevel, "server-log-level", "", serverLogLevelUsage)
+	resourceWatchCmd.Flags().BoolVar(&resourceReconnect, "reconnect", false, "Reconnect and resubscribe if the connection is lost")
+}
+
+// connectResourceClient connects and initializes a client for the resource commands
+func connectResourceClient(cmd *cobra.Command, component string) *client.Client {
+	logger := newLogger(component)
+
+	if resourceLogLevel != "" {
+		if _, err := mcp.ParseLoggingLevel(resourceLogLevel); err != nil {
+			fmt.Printf("❌ Invalid --server-log-level: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	mcpTransport, err := resourceConn.newTransport(cmd)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	config := client.ClientConfig{
+		Name:       "mcp-client-go",
+		Version:    "1.0.0",
+		Logger:     logger,
+		Timeout:    resourceTimeout,
+		Middleware: verboseMiddleware(logger),
+	}
+	if resourceReconnect {
+		policy := client.DefaultReconnectPolicy()
+		config.Reconnect = &policy
+	}
+
+	mcpClient := client.NewClient(mcpTransport, config)
+	mcpClient.OnReconnect(func() {
+		fmt.Println("🔄 Reconnected to MCP server")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout)
+	defer cancel()
+
+	if err := mcpClient.Connect(ctx); err != nil {
+		fmt.Printf("❌ Failed to connect: %v\n", err)
+		os.Exit(1)
+	}
+
+	clientInfo := mcp.ClientInfo{
+		Name:    "mcp-client-go",
+		Version: "1.0.0",
+	}
+
+	if err := mcpClient.Initialize(ctx, clientInfo); err != nil {
+		fmt.Printf("❌ Failed to initialize MCP protocol: %v\n", err)
+		mcpClient.Disconnect()
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ Connected and initialized MCP protocol")
+
+	if resourceLogLevel != "" {
+		if err := startServerLogs(ctx, mcpClient, resourceLogLevel, func(line string) { fmt.Println(line) }); err != nil {
+			fmt.Printf("⚠️  Server logs unavailable: %v\n", err)
+		}
+	}
+
+	return mcpClient
+}
+
+func runResourceTemplates(cmd *cobra.Command, args []string) {
+	mcpClient := connectResourceClient(cmd, "resource")
+	defer mcpClient.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout)
+	defer cancel()
+
+	fmt.Println("\n📋 Listing resource templates...")
+	templates, err := mcpClient.ListResourceTemplates(ctx)
+	if err != nil {
+		fmt.Printf("❌ Failed to list resource templates: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("   No resource templates available")
+		return
+	}
+
+	fmt.Printf("🧩 Available resource templates (%d):\n", len(templates))
+	printResourceTemplates(templates)
+}
+
+// printResourceTemplates prints templates with their variables
+func printResourceTemplates(templates []mcp.ResourceTemplate) {
+	for i, template := range templates {
+		fmt.Printf("  %d. %s\n", i+1, template.Name)
+		fmt.Printf("     URI template: %s\n", template.URITemplate)
+		if template.Description != "" {
+			fmt.Printf("     Description: %s\n", template.Description)
+		}
+		if variables, err := uritemplate.Variables(template.URITemplate); err == nil && len(variables) > 0 {
+			fmt.Printf("     Variables: %s\n", strings.Join(variables, ", "))
+		}
+	}
+}
+
+func runResourceWatch(cmd *cobra.Command, args []string) {
+	uri := args[0]
+
+	mcpClient := connectResourceClient(cmd, "resource")
+	defer mcpClient.Disconnect()
+
+	// Stop watching on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	updates := make(chan struct{}, 1)
+	subscribeCtx, cancel := context.WithTimeout(ctx, resourceTimeout)
+	err := mcpClient.SubscribeResource(subscribeCtx, uri, func(mcp.ResourceUpdatedNotification) {
+		// Coalesce bursts of updates into a single read
+		select {
+		case updates <- struct{}{}:
+		default:
+		}
+	})
+	cancel()
+	if err != nil {
+		fmt.Printf("❌ Failed to subscribe to %s: %v\n", uri, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("👀 Watching %s (Ctrl-C to stop)\n", uri)
+	printResource(ctx, mcpClient, uri)
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("\n🛑 Stopped watching")
+			unsubscribeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			mcpClient.UnsubscribeResource(unsubscribeCtx, uri)
+			cancel()
+			return
+		case <-updates:
+			printResource(ctx, mcpClient, uri)
+		}
+	}
+}
+
+// printResource reads a resource and prints its contents
+func printResource(ctx context.Context, mcpClient *client.Client, uri string) {
+	readCtx, cancel := context.WithTimeout(ctx, resourceTimeout)
+	defer cancel()
+
+	result, err := mcpClient.ReadResource(readCtx, uri)
+	if err != nil {
+		fmt.Printf("❌ Failed to read %s: %v\n", uri, err)
+		return
+	}
+
+	fmt.Printf("\n📄 %s [%s]\n", uri, time.Now().Format("15:04:05"))
+	for _, content := range result.Contents {
+		printResourceContents(content)
+	}
+}
@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -42,7 +45,7 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.mcp-client.yaml)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output as JSON logs")
 
 	// Bind flags to viper
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
@@ -73,3 +76,23 @@ func initConfig() {
 		}
 	}
 }
+
+// newLogger returns the logger for a command. Logs go to stdout as text at
+// info level, or as JSON including debug records when --verbose is set.
+func newLogger(component string) *slog.Logger {
+	var handler slog.Handler
+	if verbose {
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, nil)
+	}
+	return slog.New(handler).With(slog.String("component", component))
+}
+
+// verboseMiddleware logs every MCP request and its duration when --verbose is set
+func verboseMiddleware(logger *slog.Logger) []client.Middleware {
+	if !verbose {
+		return nil
+	}
+	return []client.Middleware{client.LoggingMiddleware(logger)}
+}
@@ -4,27 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
-	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	toolHost      string
-	toolPort      int
-	toolCommand   string
-	toolArgs      []string
-	toolType      string
-	toolTimeout   time.Duration
-	toolName      string
-	toolArguments string
+	toolConn       connectionFlags
+	toolTimeout    time.Duration
+	toolRoots      []string
+	toolLogLevel   string
+	toolName       string
+	toolArguments  string
+	toolNoValidate bool
+	toolStats      bool
 )
 
 // toolCmd represents the tool command
@@ -39,7 +38,11 @@ the specified tool with the provided arguments.
 Examples:
   mcp-client tool --name search --args '{"query": "golang"}' --tcp --host localhost --port 8811
   mcp-client tool --name docker --args '{"command": "ps"}' --docker
-  mcp-client tool --name fetch_content --args '{"url": "https://example.com"}' --type tcp`,
+  mcp-client tool --name fetch_content --args '{"url": "https://example.com"}' --type tcp
+
+Arguments are checked against the tool's input schema before the call is
+sent; use --no-validate to send them unchecked. Use --stats to print request
+counts and latencies when the run ends.`,
 	Run: runTool,
 }
 
@@ -47,94 +50,68 @@ func init() {
 	rootCmd.AddCommand(toolCmd)
 
 	// Connection flags (same as connect command)
-	toolCmd.Flags().StringVar(&toolType, "type", "tcp", "Connection type: tcp, stdio, or docker")
-	toolCmd.Flags().BoolP("tcp", "t", false, "Use TCP transport")
-	toolCmd.Flags().BoolP("stdio", "s", false, "Use STDIO transport")
-	toolCmd.Flags().BoolP("docker", "d", false, "Use Docker transport (alpine/socat)")
-
-	toolCmd.Flags().StringVar(&toolHost, "host", "localhost", "TCP host to connect to")
-	toolCmd.Flags().IntVar(&toolPort, "port", 8811, "TCP port to connect to")
-	toolCmd.Flags().StringVar(&toolCommand, "command", "", "Command to execute for STDIO transport")
-	toolCmd.Flags().StringSliceVar(&toolArgs, "args", []string{}, "Arguments for the command")
+	toolConn.register(toolCmd)
 	toolCmd.Flags().DurationVar(&toolTimeout, "timeout", 30*time.Second, "Connection timeout")
+	toolCmd.Flags().StringSliceVar(&toolRoots, "root", []string{}, "Directory or URI to expose to the server as a root (repeatable)")
+	toolCmd.Flags().StringVar(&toolLogLevel, "server-log-level", "", serverLogLevelUsage)
 
 	// Tool-specific flags
 	toolCmd.Flags().StringVar(&toolName, "name", "", "Name of the tool to execute (required)")
 	toolCmd.Flags().StringVar(&toolArguments, "arguments", "{}", "JSON arguments for the tool")
+	toolCmd.Flags().BoolVar(&toolNoValidate, "no-validate", false, "Send arguments without checking them against the tool's input schema")
+	toolCmd.Flags().BoolVar(&toolStats, "stats", false, "Print request statistics at the end of the run")
 
 	// Mark required flags
 	toolCmd.MarkFlagRequired("name")
 }
 
 func runTool(cmd *cobra.Command, args []string) {
-	logger := log.New(os.Stdout, "", 0)
-	if verbose {
-		logger = log.New(os.Stdout, "[TOOL] ", log.LstdFlags)
-	}
-
-	// Determine transport type from flags
-	tcpFlag, _ := cmd.Flags().GetBool("tcp")
-	stdioFlag, _ := cmd.Flags().GetBool("stdio")
-	dockerFlag, _ := cmd.Flags().GetBool("docker")
-
-	transportType := toolType
-	if tcpFlag {
-		transportType = "tcp"
-	} else if stdioFlag {
-		transportType = "stdio"
-	} else if dockerFlag {
-		transportType = "docker"
-	}
+	logger := newLogger("tool")
 
-	fmt.Printf("🔌 Connecting to MCP server using %s transport...\n", transportType)
-
-	// Create transport based on type
-	var mcpTransport transport.Transport
-
-	switch transportType {
-	case "tcp":
-		fmt.Printf("   Host: %s:%d\n", toolHost, toolPort)
-		mcpTransport = transport.NewTCPTransport(toolHost, toolPort)
-
-	case "stdio":
-		if toolCommand == "" {
-			fmt.Println("❌ STDIO transport requires --command flag")
-			os.Exit(1)
-		}
-		fmt.Printf("   Command: %s %s\n", toolCommand, strings.Join(toolArgs, " "))
-		mcpTransport = transport.NewStdioTransport(toolCommand, toolArgs)
-
-	case "docker":
-		fmt.Println("   Using Docker alpine/socat -> host.docker.internal:8811")
-		dockerCommand := "docker"
-		dockerArgs := []string{
-			"run", "-i", "--rm", "alpine/socat",
-			"STDIO", "TCP:host.docker.internal:8811",
-		}
-		mcpTransport = transport.NewStdioTransport(dockerCommand, dockerArgs)
-
-	default:
-		fmt.Printf("❌ Unsupported transport type: %s\n", transportType)
+	mcpTransport, err := toolConn.newTransport(cmd)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
 	// Create client
 	clientConfig := client.ClientConfig{
-		Name:    "mcp-client-go",
-		Version: "1.0.0",
-		Logger:  logger,
-		Timeout: toolTimeout,
+		Name:              "mcp-client-go",
+		Version:           "1.0.0",
+		Logger:            logger,
+		Timeout:           toolTimeout,
+		ValidateArguments: !toolNoValidate,
+		Middleware:        verboseMiddleware(logger),
+	}
+	if toolStats {
+		clientConfig.Metrics = client.NewMetrics()
 	}
 
 	mcpClient := client.NewClient(mcpTransport, clientConfig)
 
+	if len(toolRoots) > 0 {
+		roots, err := rootsFromPaths(toolRoots)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			exitWithStats(clientConfig.Metrics, 1)
+		}
+		mcpClient.SetRoots(roots)
+	}
+
+	if toolLogLevel != "" {
+		if _, err := mcp.ParseLoggingLevel(toolLogLevel); err != nil {
+			fmt.Printf("❌ Invalid --server-log-level: %v\n", err)
+			exitWithStats(clientConfig.Metrics, 1)
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), toolTimeout)
 	defer cancel()
 
 	// Connect to server
 	if err := mcpClient.Connect(ctx); err != nil {
 		fmt.Printf("❌ Failed to connect: %v\n", err)
-		os.Exit(1)
+		exitWithStats(clientConfig.Metrics, 1)
 	}
 	defer mcpClient.Disconnect()
 
@@ -146,17 +123,25 @@ func runTool(cmd *cobra.Command, args []string) {
 
 	if err := mcpClient.Initialize(ctx, clientInfo); err != nil {
 		fmt.Printf("❌ Failed to initialize MCP protocol: %v\n", err)
-		os.Exit(1)
+		exitWithStats(clientConfig.Metrics, 1)
 	}
 
 	fmt.Println("✅ Connected and initialized MCP protocol")
 
+	// Server log messages are printed above the progress bar
+	progress := newProgressBar()
+	if toolLogLevel != "" {
+		if err := startServerLogs(ctx, mcpClient, toolLogLevel, progress.println); err != nil {
+			fmt.Printf("⚠️  Server logs unavailable: %v\n", err)
+		}
+	}
+
 	// Parse tool arguments
 	var arguments map[string]interface{}
 	if toolArguments != "" {
 		if err := json.Unmarshal([]byte(toolArguments), &arguments); err != nil {
 			fmt.Printf("❌ Invalid JSON arguments: %v\n", err)
-			os.Exit(1)
+			exitWithStats(clientConfig.Metrics, 1)
 		}
 	}
 
@@ -166,10 +151,23 @@ func runTool(cmd *cobra.Command, args []string) {
 		fmt.Printf("📝 Arguments: %s\n", toolArguments)
 	}
 
-	result, err := mcpClient.CallTool(ctx, toolName, arguments)
+	// Ctrl-C cancels the call and tells the server to stop working on it
+	callCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	result, err := mcpClient.CallToolWithProgress(callCtx, toolName, arguments, progress.update)
+	progress.finish()
 	if err != nil {
+		if ctx.Err() == nil && callCtx.Err() != nil {
+			fmt.Println("\n🛑 Tool execution cancelled")
+			mcpClient.Disconnect()
+			exitWithStats(clientConfig.Metrics, 130)
+		}
+		if printArgumentErrors(toolName, err) {
+			exitWithStats(clientConfig.Metrics, 1)
+		}
 		fmt.Printf("❌ Tool execution failed: %v\n", err)
-		os.Exit(1)
+		exitWithStats(clientConfig.Metrics, 1)
 	}
 
 	// Display result
@@ -183,24 +181,27 @@ func runTool(cmd *cobra.Command, args []string) {
 			fmt.Printf("\n--- Content %d ---\n", i+1)
 		}
 
-		switch content.Type {
-		case "text":
-			fmt.Println(content.Text)
-		case "image":
-			fmt.Printf("Image content: %s\n", content.Data)
-			if content.MimeType != "" {
-				fmt.Printf("MIME type: %s\n", content.MimeType)
-			}
-		default:
-			fmt.Printf("Content type: %s\n", content.Type)
-			if content.Data != "" {
-				fmt.Printf("Data: %s\n", content.Data)
-			}
-			if content.Text != "" {
-				fmt.Printf("Text: %s\n", content.Text)
-			}
-		}
+		printContent(content)
 	}
 
+	if result.StructuredContent != nil {
+		fmt.Println("\n🧱 Structured content:")
+		printStructuredContent(result.StructuredContent)
+	}
+
+	printToolStats(clientConfig.Metrics)
 	fmt.Println("\n✅ Tool execution completed")
 }
+
+// printToolStats prints the request statistics collected for --stats
+func printToolStats(metrics *client.Metrics) {
+	if metrics != nil {
+		printMetricsSummary(metrics.Snapshot())
+	}
+}
+
+// exitWithStats prints the --stats summary and exits with code
+func exitWithStats(metrics *client.Metrics, code int) {
+	printToolStats(metrics)
+	os.Exit(code)
+}
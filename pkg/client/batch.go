@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
+)
+
+// BatchItem is one request of a batch
+type BatchItem struct {
+	Method string
+	Params interface{}
+}
+
+// BatchResult is the outcome of one BatchItem
+type BatchResult struct {
+	// Response is the server's response, which may carry a JSON-RPC error
+	Response *mcp.Message
+	// Err is set if no response was received
+	Err error
+}
+
+// Decode returns the error of the request, or decodes its result into out
+func (r BatchResult) Decode(out interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if r.Response.Error != nil {
+		return newMCPError(r.Response.Error)
+	}
+	if out == nil {
+		return nil
+	}
+	return parseResult(r.Response.Result, out)
+}
+
+// Batch sends several requests in one JSON-RPC batch frame and returns their
+// results in the same order.
+//
+// Each request passes through the middleware chain on its own. Requests are
+// only combined into one frame when the negotiated protocol version supports
+// batching and the transport implements transport.BatchSender; otherwise
+// they are sent one after another, so Batch works with every server. Only
+// protocol 2025-03-26 has batching; it was removed in 2025-06-18, the version
+// the client asks for. With a server that negotiates 2025-06-18, Batch sends
+// every request in a frame of its own.
+//
+// The frame is sent once every request has reached the transport or ended,
+// or 50ms after the first one did, so middleware that waits for another
+// request of the same batch cannot hold up the frame. Requests that
+// arrive after the frame was sent, and requests retried after a reconnect,
+// are sent on their own.
+//
+// Failures of single requests are reported in their BatchResult; the error is
+// only set if the batch could not be sent at all. A request whose context
+// ends before the frame is sent is left out of the frame, and one that ends
+// after is cancelled with notifications/cancelled; neither affects the
+// session.
+//
+// Example:
+//
+//	results, err := client.Batch(ctx,
+//		client.BatchItem{Method: "tools/list"},
+//		client.BatchItem{Method: "prompts/list"},
+//	)
+//	var tools mcp.ListToolsResponse
+//	if err := results[0].Decode(&tools); err != nil {
+//		// handle the error of the tools/list request
+//	}
+func (c *Client) Batch(ctx context.Context, items ...BatchItem) ([]BatchResult, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	frame := &batchFrame{
+		client:   c,
+		combine:  c.SupportsFeature(mcp.FeatureBatching),
+		waiting:  len(items),
+		messages: make([]*mcp.Message, len(items)),
+		sent:     make(chan struct{}),
+	}
+
+	results := make([]BatchResult, len(items))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item BatchItem) {
+			defer wg.Done()
+
+			slot := &batchSlot{frame: frame, index: i}
+			itemCtx := context.WithValue(ctx, batchSlotKey{}, slot)
+			response, err := c.request(itemCtx, item.Method, item.Params, idempotentMethods[item.Method])
+			// Middleware may have answered without sending anything
+			slot.release()
+			results[i] = BatchResult{Response: response, Err: err}
+		}(i, item)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+type batchSlotKey struct{}
+
+// batchSlot is the place of one request in a batch frame. It is either taken
+// by the request or released, so the frame stops waiting for it.
+type batchSlot struct {
+	frame *batchFrame
+	index int
+
+	mu   sync.Mutex
+	done bool
+}
+
+// take claims the slot for the request being sent. It fails if the slot was
+// already taken or released.
+func (s *batchSlot) take() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return false
+	}
+	s.done = true
+	return true
+}
+
+// release gives up the slot if it was not taken
+func (s *batchSlot) release() {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+	s.done = true
+	s.mu.Unlock()
+
+	s.frame.add(s.index, nil)
+}
+
+// batchFrame collects the requests of a batch and sends them together once
+// every slot was taken or released, or batchFlushDelay after the first
+// request was added
+type batchFrame struct {
+	client  *Client
+	combine bool
+
+	mu       sync.Mutex
+	waiting  int
+	flushed  bool
+	timer    *time.Timer
+	messages []*mcp.Message
+	sent     chan struct{}
+	err      error
+}
+
+// batchFlushDelay is how long a batch frame waits for its remaining requests
+// after the first one was added
+const batchFlushDelay = 50 * time.Millisecond
+
+// errWithdrawn is returned by join for a request taken out of its frame
+// before the frame was sent, so nothing reached the server
+var errWithdrawn = errors.New("request withdrawn from batch")
+
+// add places message at index, or nothing if it is nil, and sends the frame
+// when it is complete. It reports false if the frame was already sent.
+func (f *batchFrame) add(index int, message *mcp.Message) bool {
+	f.mu.Lock()
+	if f.flushed {
+		f.mu.Unlock()
+		return false
+	}
+	f.messages[index] = message
+	f.waiting--
+	if message != nil && f.timer == nil {
+		f.timer = time.AfterFunc(batchFlushDelay, f.flush)
+	}
+	complete := f.waiting == 0
+	f.mu.Unlock()
+
+	if complete {
+		f.flush()
+	}
+	return true
+}
+
+// flush sends the requests added so far. Only the first call sends.
+func (f *batchFrame) flush() {
+	f.mu.Lock()
+	if f.flushed {
+		f.mu.Unlock()
+		return
+	}
+	f.flushed = true
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	var messages []*mcp.Message
+	for _, message := range f.messages {
+		if message != nil {
+			messages = append(messages, message)
+		}
+	}
+	f.mu.Unlock()
+
+	if len(messages) > 0 {
+		f.err = f.client.sendBatch(messages, f.combine)
+	}
+	close(f.sent)
+}
+
+// join adds a request to the frame and waits until the frame was sent. A
+// request whose context ends first is withdrawn from the frame; if the frame
+// is already being sent, join returns nil so the caller cancels the request
+// like any other sent request.
+func (f *batchFrame) join(ctx context.Context, index int, message *mcp.Message) error {
+	if !f.add(index, message) {
+		// The frame left without this request
+		return f.client.send(message)
+	}
+
+	select {
+	case <-f.sent:
+		return f.err
+	case <-ctx.Done():
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.flushed {
+			return nil
+		}
+		f.messages[index] = nil
+		return fmt.Errorf("%w: %w", errWithdrawn, ctx.Err())
+	}
+}
+
+// transmit sends a request, or adds it to the batch frame it belongs to
+func (c *Client) transmit(ctx context.Context, request *mcp.Message) error {
+	if slot, ok := ctx.Value(batchSlotKey{}).(*batchSlot); ok && slot.take() {
+		return slot.frame.join(ctx, slot.index, request)
+	}
+	return c.send(request)
+}
+
+// releaseBatchSlot stops the batch frame of ctx from waiting for a request
+// that ended before it was sent
+func releaseBatchSlot(ctx context.Context) {
+	if slot, ok := ctx.Value(batchSlotKey{}).(*batchSlot); ok {
+		slot.release()
+	}
+}
+
+// sendBatch writes the messages of a batch, as one frame if combine is set
+// and the transport supports it
+func (c *Client) sendBatch(messages []*mcp.Message, combine bool) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	if sender, ok := c.transport.(transport.BatchSender); ok && combine && len(messages) > 1 {
+		return sender.SendBatch(messages)
+	}
+	for _, message := range messages {
+		if err := c.transport.Send(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
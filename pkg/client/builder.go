@@ -1,10 +1,12 @@
 package client
 
 import (
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ClientBuilder provides a fluent interface for building MCP clients
@@ -61,7 +63,7 @@ func (b *ClientBuilder) WithVersion(version string) *ClientBuilder {
 }
 
 // WithLogger sets the logger
-func (b *ClientBuilder) WithLogger(logger *log.Logger) *ClientBuilder {
+func (b *ClientBuilder) WithLogger(logger *slog.Logger) *ClientBuilder {
 	b.config.Logger = logger
 	return b
 }
@@ -72,6 +74,65 @@ func (b *ClientBuilder) WithTimeout(timeout time.Duration) *ClientBuilder {
 	return b
 }
 
+// WithMaxPages sets how many pages list operations follow
+func (b *ClientBuilder) WithMaxPages(maxPages int) *ClientBuilder {
+	b.config.MaxPages = maxPages
+	return b
+}
+
+// WithSamplingHandler sets the handler used to answer sampling requests
+func (b *ClientBuilder) WithSamplingHandler(handler SamplingHandler) *ClientBuilder {
+	b.config.SamplingHandler = handler
+	return b
+}
+
+// WithLogSink sets the sink that receives server log messages
+func (b *ClientBuilder) WithLogSink(sink LogSink) *ClientBuilder {
+	b.config.LogSink = sink
+	return b
+}
+
+// WithReconnect enables automatic reconnects using policy. Zero fields other
+// than Jitter take their values from DefaultReconnectPolicy.
+func (b *ClientBuilder) WithReconnect(policy ReconnectPolicy) *ClientBuilder {
+	b.config.Reconnect = &policy
+	return b
+}
+
+// WithCatalogCache caches the tool, resource and prompt lists until the
+// server reports a change
+func (b *ClientBuilder) WithCatalogCache() *ClientBuilder {
+	b.config.CacheCatalog = true
+	return b
+}
+
+// WithMiddleware adds middleware wrapping every request sent to and received
+// from the server. Middleware runs in the order it is added.
+func (b *ClientBuilder) WithMiddleware(middleware ...Middleware) *ClientBuilder {
+	b.config.Middleware = append(b.config.Middleware, middleware...)
+	return b
+}
+
+// WithMetrics records request counts, latencies and connection state in metrics
+func (b *ClientBuilder) WithMetrics(metrics *Metrics) *ClientBuilder {
+	b.config.Metrics = metrics
+	return b
+}
+
+// WithTracing creates OpenTelemetry spans for requests using provider and
+// propagates the trace context to the server
+func (b *ClientBuilder) WithTracing(provider trace.TracerProvider) *ClientBuilder {
+	b.config.TracerProvider = provider
+	return b
+}
+
+// WithArgumentValidation makes CallTool validate arguments against the
+// tool's input schema before sending them
+func (b *ClientBuilder) WithArgumentValidation() *ClientBuilder {
+	b.config.ValidateArguments = true
+	return b
+}
+
 // Build creates the MCP client
 func (b *ClientBuilder) Build() *Client {
 	if b.transport == nil {
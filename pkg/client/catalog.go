@@ -0,0 +1,234 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// CatalogDiff describes how the server's tool list changed
+type CatalogDiff struct {
+	Added   []mcp.Tool
+	Removed []mcp.Tool
+	// Changed holds the new definitions of tools that kept their name
+	Changed []mcp.Tool
+}
+
+// Empty reports whether the diff has no changes
+func (d CatalogDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// catalogEntry holds the last list fetched for one part of the catalog
+type catalogEntry[T any] struct {
+	items   []T
+	fetched bool
+	// stale is set when the server reported a change since items were fetched
+	stale bool
+	// valid is set while items can be served without asking the server
+	valid bool
+	// version is bumped on invalidation so a fetch racing with a
+	// list_changed notification is not cached
+	version int
+}
+
+// invalidate makes the next list call ask the server again
+func (e *catalogEntry[T]) invalidate() {
+	e.valid = false
+	e.stale = true
+	e.version++
+}
+
+// catalog caches the tool, resource and prompt lists of the session
+type catalog struct {
+	tools     catalogEntry[mcp.Tool]
+	resources catalogEntry[mcp.Resource]
+	prompts   catalogEntry[mcp.Prompt]
+
+	// refreshing is set while refreshTools runs, and refreshAgain when a
+	// tools list_changed notification arrived during the refresh
+	refreshing   bool
+	refreshAgain bool
+}
+
+// listCached returns the cached list in entry or fetches it.
+//
+// A fetched list is always remembered so tool diffs have something to compare
+// against, but it is only served from the cache when caching is enabled and
+// the server announces changes with list_changed notifications.
+func listCached[T any](c *Client, entry *catalogEntry[T], listChanged bool, fetch func() ([]T, error)) ([]T, error) {
+	c.catalogMu.Lock()
+	if c.cacheCatalog && entry.valid {
+		items := append([]T(nil), entry.items...)
+		c.catalogMu.Unlock()
+		return items, nil
+	}
+	version := entry.version
+	c.catalogMu.Unlock()
+
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.catalogMu.Lock()
+	if entry.version == version {
+		entry.items = append([]T(nil), items...)
+		entry.fetched = true
+		entry.stale = false
+		entry.valid = listChanged
+	}
+	c.catalogMu.Unlock()
+
+	return items, nil
+}
+
+// InvalidateCatalog drops the cached tool, resource and prompt lists, so the
+// next list call asks the server again
+func (c *Client) InvalidateCatalog() {
+	c.catalogMu.Lock()
+	defer c.catalogMu.Unlock()
+	c.catalog.tools.invalidate()
+	c.catalog.resources.invalidate()
+	c.catalog.prompts.invalidate()
+}
+
+// OnCatalogChanged registers a handler called with the differences whenever
+// the server reports that its tool list changed.
+//
+// The new list is fetched before handlers run, so it is only reported once
+// the tools have been listed at least once. Handlers are not called if
+// nothing actually changed. They run on a goroutine of their own, so
+// notifications keep being delivered while the list is fetched.
+func (c *Client) OnCatalogChanged(handler func(CatalogDiff)) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.catalogHandlers = append(c.catalogHandlers, handler)
+}
+
+// cachedTool returns the last known definition of the named tool
+func (c *Client) cachedTool(name string) (mcp.Tool, bool) {
+	c.catalogMu.Lock()
+	defer c.catalogMu.Unlock()
+
+	for _, tool := range c.catalog.tools.items {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return mcp.Tool{}, false
+}
+
+// dispatchListChanged invalidates the part of the catalog named by a
+// list_changed notification. Tool changes are also reported to the
+// OnCatalogChanged handlers.
+func (c *Client) dispatchListChanged(notification *mcp.Message) bool {
+	c.catalogMu.Lock()
+	switch notification.Method {
+	case mcp.NotificationResourcesListChanged:
+		c.catalog.resources.invalidate()
+	case mcp.NotificationPromptsListChanged:
+		c.catalog.prompts.invalidate()
+	}
+	if notification.Method != mcp.NotificationToolsListChanged {
+		c.catalogMu.Unlock()
+		return false
+	}
+	previous, fetched := c.catalog.tools.items, c.catalog.tools.fetched
+	c.catalog.tools.invalidate()
+	c.catalogMu.Unlock()
+
+	c.handlersMu.RLock()
+	handlers := len(c.catalogHandlers) > 0
+	c.handlersMu.RUnlock()
+
+	if !handlers || !fetched {
+		return false
+	}
+
+	// The refresh waits for a response that readLoop can only deliver while
+	// this goroutine keeps draining notifications, so it runs on its own
+	c.catalogMu.Lock()
+	running := c.catalog.refreshing
+	c.catalog.refreshing = true
+	c.catalog.refreshAgain = running
+	c.catalogMu.Unlock()
+
+	if !running {
+		go c.refreshTools(previous)
+	}
+	return true
+}
+
+// refreshTools lists the tools again and reports the differences to
+// previous to the OnCatalogChanged handlers, repeating while more tools
+// list_changed notifications arrive
+func (c *Client) refreshTools(previous []mcp.Tool) {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		tools, err := c.ListTools(ctx)
+		cancel()
+
+		if err != nil {
+			c.log().Warn("Failed to refresh tools", slog.String("method", mcp.NotificationToolsListChanged), slog.Any("error", err))
+		} else {
+			if diff := diffTools(previous, tools); !diff.Empty() {
+				c.handlersMu.RLock()
+				handlers := c.catalogHandlers
+				c.handlersMu.RUnlock()
+
+				for _, handler := range handlers {
+					handler(diff)
+				}
+			}
+			previous = tools
+		}
+
+		c.catalogMu.Lock()
+		again := c.catalog.refreshAgain
+		c.catalog.refreshAgain = false
+		c.catalog.refreshing = again
+		c.catalogMu.Unlock()
+
+		if !again {
+			return
+		}
+	}
+}
+
+// diffTools compares two tool lists by name
+func diffTools(previous, current []mcp.Tool) CatalogDiff {
+	old := make(map[string]mcp.Tool, len(previous))
+	for _, tool := range previous {
+		old[tool.Name] = tool
+	}
+
+	var diff CatalogDiff
+	seen := make(map[string]bool, len(current))
+	for _, tool := range current {
+		seen[tool.Name] = true
+		before, ok := old[tool.Name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, tool)
+		case !sameTool(before, tool):
+			diff.Changed = append(diff.Changed, tool)
+		}
+	}
+	for _, tool := range previous {
+		if !seen[tool.Name] {
+			diff.Removed = append(diff.Removed, tool)
+		}
+	}
+	return diff
+}
+
+// sameTool compares tool definitions by their JSON encoding
+func sameTool(a, b mcp.Tool) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
@@ -33,15 +33,21 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/uritemplate"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Client represents an MCP client
@@ -49,20 +55,138 @@ type Client struct {
 	transport          transport.Transport
 	serverInfo         *mcp.ServerInfo
 	serverCapabilities *mcp.ServerCapabilities
+	protocolVersion    string
 	connected          bool
 	initialized        bool
 	mu                 sync.RWMutex
 	requestID          int64
-	logger             *log.Logger
-	timeout            time.Duration
+	// logger carries the server name once initialized; baseLogger is the
+	// configured logger
+	logger     atomic.Pointer[slog.Logger]
+	baseLogger *slog.Logger
+	timeout    time.Duration
+	maxPages   int
+
+	// sendMu serializes writes to the transport
+	sendMu sync.Mutex
+
+	// pending maps in-flight request IDs to the channel their response is
+	// delivered on. It is owned by the reader goroutine started in Connect.
+	pending   map[int64]chan *mcp.Message
+	pendingMu sync.Mutex
+	readErr   error
+	readDone  chan struct{}
+
+	// cancelSession cancels the context handed to server request handlers
+	cancelSession context.CancelFunc
+
+	handlersMu           sync.RWMutex
+	notificationHandlers map[string][]NotificationHandler
+	requestHandlers      map[string]RequestHandler
+	samplingHandler      SamplingHandler
+	roots                []mcp.Root
+	// rootsAdvertised is set when the session offered roots with listChanged
+	rootsAdvertised bool
+
+	// progressHandlers maps progress tokens of in-flight requests to callbacks
+	progressHandlers map[string]func(mcp.ProgressNotification)
+	progressToken    int64
+
+	// subscriptions maps subscribed resource URIs to update callbacks
+	subscriptions map[string]func(mcp.ResourceUpdatedNotification)
+
+	// logSink receives notifications/message; logLevel is restored on Initialize
+	logSink  LogSink
+	logLevel mcp.LoggingLevel
+
+	// reconnectPolicy restores lost sessions when set. clientInfo is kept from
+	// Initialize for the new session; generation counts connections so that
+	// concurrent failures share one reconnect. closed is set by Disconnect.
+	reconnectPolicy   *ReconnectPolicy
+	reconnectHandlers []func()
+	reconnecting      *reconnectAttempt
+	cancelReconnect   context.CancelFunc
+	clientInfo        *mcp.ClientInfo
+	generation        int64
+	closed            bool
+
+	// catalog remembers the listed tools, resources and prompts; it serves
+	// them without a round trip when cacheCatalog is set
+	catalogMu       sync.Mutex
+	catalog         catalog
+	cacheCatalog    bool
+	catalogHandlers []func(CatalogDiff)
+
+	// validateArguments makes CallTool check arguments against the tool's
+	// input schema before sending
+	validateArguments bool
+
+	// outgoing and incoming are the middleware chains wrapped around sending
+	// requests and answering server requests
+	outgoing Handler
+	incoming Handler
+
+	// metrics counts requests and connections when set
+	metrics *Metrics
+
+	// tracing creates spans for requests when set
+	tracing *tracing
 }
 
 // ClientConfig holds configuration for the MCP client
 type ClientConfig struct {
 	Name    string
 	Version string
-	Logger  *log.Logger
+	// Logger receives structured records with fields such as request_id,
+	// method, server and error. Routine operations are logged at debug level.
+	Logger  *slog.Logger
 	Timeout time.Duration
+
+	// MaxPages limits how many pages ListTools, ListResources,
+	// ListResourceTemplates and ListPrompts follow before giving up.
+	// Defaults to 100.
+	MaxPages int
+
+	// SamplingHandler answers sampling/createMessage requests. The sampling
+	// capability is only advertised when it is set.
+	SamplingHandler SamplingHandler
+
+	// LogSink receives log messages sent by the server. Use SetLogLevel to
+	// choose which messages the server sends.
+	LogSink LogSink
+
+	// Reconnect enables automatic reconnects when the connection is lost.
+	// Nil disables them.
+	Reconnect *ReconnectPolicy
+
+	// CacheCatalog makes ListTools, ListResources and ListPrompts answer
+	// from a cache after the first call. A list is only cached if the server
+	// advertises listChanged for it, and is dropped when the server sends the
+	// matching list_changed notification or a new session is initialized.
+	CacheCatalog bool
+
+	// ValidateArguments makes CallTool check arguments against the tool's
+	// input schema and return a *ValidationError instead of sending invalid
+	// requests. The schema is taken from the last tool list.
+	ValidateArguments bool
+
+	// Middleware wraps every request sent to the server and every request
+	// received from it. The first middleware is the outermost.
+	Middleware []Middleware
+
+	// Metrics records request counts, latencies, requests in flight and open
+	// connections. Nil disables metrics.
+	Metrics *Metrics
+
+	// TracerProvider enables OpenTelemetry tracing: every request gets a span
+	// and carries its trace context in params._meta. Notifications received
+	// while a request is in flight are recorded as span events. Nil disables
+	// tracing.
+	TracerProvider trace.TracerProvider
+
+	// Propagator encodes the trace context into params._meta. Defaults to
+	// the W3C trace context (traceparent and tracestate).
+	Propagator propagation.TextMapPropagator
 }
 
 // NewClient creates a new MCP client with the given transport and configuration.
@@ -70,8 +194,9 @@ type ClientConfig struct {
 // The transport parameter specifies how to communicate with the MCP server (TCP, STDIO, etc.).
 // The config parameter allows customization of client behavior including logging and timeouts.
 //
-// If config.Logger is nil, log.Default() will be used.
+// If config.Logger is nil, slog.Default() will be used.
 // If config.Timeout is 0, a default timeout of 30 seconds will be used.
+// If config.MaxPages is 0, list operations follow at most 100 pages.
 //
 // Example:
 //
@@ -85,17 +210,54 @@ type ClientConfig struct {
 //	client := NewClient(transport, config)
 func NewClient(transport transport.Transport, config ClientConfig) *Client {
 	if config.Logger == nil {
-		config.Logger = log.Default()
+		config.Logger = slog.Default()
 	}
 	if config.Timeout == 0 {
 		config.Timeout = 30 * time.Second
 	}
+	if config.MaxPages <= 0 {
+		config.MaxPages = 100
+	}
+
+	c := &Client{
+		transport:  transport,
+		baseLogger: config.Logger,
+		timeout:    config.Timeout,
+		maxPages:   config.MaxPages,
+		pending:    make(map[int64]chan *mcp.Message),
+
+		notificationHandlers: make(map[string][]NotificationHandler),
+		requestHandlers:      make(map[string]RequestHandler),
+		progressHandlers:     make(map[string]func(mcp.ProgressNotification)),
+		subscriptions:        make(map[string]func(mcp.ResourceUpdatedNotification)),
+		logSink:              config.LogSink,
+		reconnectPolicy:      config.Reconnect,
+		cacheCatalog:         config.CacheCatalog,
+		validateArguments:    config.ValidateArguments,
+		metrics:              config.Metrics,
+	}
+
+	c.logger.Store(config.Logger)
 
-	return &Client{
-		transport: transport,
-		logger:    config.Logger,
-		timeout:   config.Timeout,
+	// Tracing runs outermost so the span covers all other middleware
+	var middleware []Middleware
+	if config.TracerProvider != nil {
+		c.tracing = newTracing(config.TracerProvider, config.Propagator)
+		middleware = append(middleware, c.traceMiddleware())
 	}
+	middleware = append(middleware, config.Middleware...)
+	if config.Metrics != nil {
+		middleware = append(middleware, config.Metrics.middleware())
+	}
+	chain := Chain(middleware...)
+	c.outgoing = chain(c.exchange)
+	c.incoming = chain(c.answerRequest)
+
+	if config.SamplingHandler != nil {
+		c.SetSamplingHandler(config.SamplingHandler)
+	}
+
+	return c
 }
 
 // Connect establishes connection to the MCP server.
@@ -111,21 +273,47 @@ func (c *Client) Connect(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.closed = false
 	if c.connected {
 		return nil
 	}
 
-	c.logger.Println("Connecting to MCP server...")
+	return c.connectLocked(ctx)
+}
+
+// connectLocked connects the transport and starts the reader. c.mu must be held.
+func (c *Client) connectLocked(ctx context.Context) error {
+	c.log().Debug("Connecting to MCP server")
 
 	if err := c.transport.Connect(ctx); err != nil {
-		return fmt.Errorf("failed to connect transport: %w", err)
+		return NewTransportError(transportName(c.transport), "failed to connect transport", err)
 	}
 
-	c.connected = true
-	c.logger.Println("Connected to MCP server")
+	c.startSessionLocked()
 	return nil
 }
 
+// startSessionLocked marks the freshly connected transport as the current
+// connection and starts reading from it. c.mu must be held.
+func (c *Client) startSessionLocked() {
+	c.setConnectedLocked(true)
+	c.generation++
+
+	c.pendingMu.Lock()
+	c.readErr = nil
+	c.pendingMu.Unlock()
+
+	done := make(chan struct{})
+	messages := make(chan *mcp.Message, 100)
+	sessionCtx, cancel := context.WithCancel(context.Background())
+	c.readDone = done
+	c.cancelSession = cancel
+	go c.readLoop(sessionCtx, done, messages, c.generation)
+	go c.notifyLoop(sessionCtx, messages)
+
+	c.log().Info("Connected to MCP server")
+}
+
 // Initialize performs the MCP protocol initialization handshake.
 //
 // This method must be called after Connect() and before any tool or resource operations.
@@ -134,34 +322,32 @@ func (c *Client) Connect(ctx context.Context) error {
 // The clientInfo parameter identifies this client to the server and should contain
 // a meaningful name and version.
 //
+// The client offers mcp.Version and accepts any version in
+// mcp.SupportedProtocolVersions the server answers with. If the server picks
+// a version the client does not support, Initialize returns an
+// *UnsupportedProtocolVersionError and the session stays uninitialized.
+//
 // Returns an error if initialization fails or if the client is not connected.
 func (c *Client) Initialize(ctx context.Context, clientInfo mcp.ClientInfo) error {
 	if !c.IsConnected() {
 		return ErrNotConnected
 	}
-	c.logger.Printf("Initializing MCP protocol with client: %s %s", clientInfo.Name, clientInfo.Version)
+	c.log().Debug("Initializing MCP protocol",
+		slog.String("client", clientInfo.Name), slog.String("client_version", clientInfo.Version))
 
-	c.logger.Printf("Creating initialize request...")
 	// Create initialize request
 	request := mcp.InitializeRequest{
 		ProtocolVersion: mcp.Version,
-		Capabilities: mcp.ClientCapabilities{
-			Experimental: make(map[string]interface{}),
-			Sampling:     &mcp.SamplingCapability{},
-		},
-		ClientInfo: clientInfo,
+		Capabilities:    c.clientCapabilities(),
+		ClientInfo:      clientInfo,
 	}
-	c.logger.Printf("Initialize request created successfully")
 	// Send initialize request
-	c.logger.Printf("Sending initialize request...")
 	response, err := c.sendRequest(ctx, "initialize", request)
 	if err != nil {
 		return fmt.Errorf("initialize request failed: %w", err)
 	}
-	c.logger.Printf("Received initialize response")
-
 	if response.Error != nil {
-		return fmt.Errorf("initialize error: %s", response.Error.Message)
+		return fmt.Errorf("initialize error: %w", newMCPError(response.Error))
 	}
 
 	// Parse initialize response
@@ -170,42 +356,103 @@ func (c *Client) Initialize(ctx context.Context, clientInfo mcp.ClientInfo) erro
 		return fmt.Errorf("failed to parse initialize response: %w", err)
 	}
 
+	if !mcp.IsSupportedProtocolVersion(initResponse.ProtocolVersion) {
+		return &UnsupportedProtocolVersionError{
+			Requested: request.ProtocolVersion,
+			Received:  initResponse.ProtocolVersion,
+			Supported: mcp.SupportedProtocolVersions,
+		}
+	}
+
+	// The server only expects roots/list_changed if this session offered it
+	roots := request.Capabilities.Roots
+	c.handlersMu.Lock()
+	c.rootsAdvertised = roots != nil && roots.ListChanged
+	c.handlersMu.Unlock()
+
 	c.mu.Lock()
 	c.serverInfo = &initResponse.ServerInfo
 	c.serverCapabilities = &initResponse.Capabilities
+	c.protocolVersion = initResponse.ProtocolVersion
+	c.clientInfo = &clientInfo
 	c.initialized = true
 	c.mu.Unlock()
 
-	c.logger.Printf("MCP protocol initialized. Server: %s %s",
-		initResponse.ServerInfo.Name, initResponse.ServerInfo.Version)
+	// The lists of a previous session may be stale
+	c.InvalidateCatalog()
+
+	// Records of this session carry the server name
+	c.logger.Store(c.baseLogger.With(slog.String("server", initResponse.ServerInfo.Name)))
+	c.log().Info("MCP protocol initialized",
+		slog.String("server_version", initResponse.ServerInfo.Version),
+		slog.String("protocol_version", initResponse.ProtocolVersion))
 
 	// Send initialized notification
-	notification := mcp.NewNotification("notifications/initialized", nil)
-	if err := c.transport.Send(notification); err != nil {
+	notification := mcp.NewNotification(mcp.NotificationInitialized, nil)
+	if err := c.send(notification); err != nil {
 		return fmt.Errorf("failed to send initialized notification: %w", err)
 	}
 
+	c.restoreSubscriptions(ctx)
+	c.restoreLogLevel(ctx)
+
 	return nil
 }
 
-// Disconnect closes the connection to the MCP server
+// clientCapabilities returns the capabilities advertised during Initialize
+func (c *Client) clientCapabilities() mcp.ClientCapabilities {
+	c.handlersMu.RLock()
+	defer c.handlersMu.RUnlock()
+
+	capabilities := mcp.ClientCapabilities{
+		Experimental: make(map[string]interface{}),
+	}
+	if c.roots != nil {
+		capabilities.Roots = &mcp.RootsCapability{ListChanged: true}
+	}
+	if c.samplingHandler != nil {
+		capabilities.Sampling = &mcp.SamplingCapability{}
+	}
+	return capabilities
+}
+
+// Disconnect closes the connection to the MCP server and stops any
+// reconnect in progress
 func (c *Client) Disconnect() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.closed = true
+	c.clientInfo = nil
+	if c.cancelReconnect != nil {
+		c.cancelReconnect()
+	}
+
 	if !c.connected {
 		return nil
 	}
 
-	c.logger.Println("Disconnecting from MCP server...")
+	c.log().Debug("Disconnecting from MCP server")
+	err := c.resetLocked(ErrConnectionClosed)
+	c.log().Info("Disconnected from MCP server")
+	return err
+}
 
+// resetLocked closes the transport, forgets the session and fails pending
+// requests with cause. c.mu must be held.
+func (c *Client) resetLocked(cause error) error {
 	err := c.transport.Close()
-	c.connected = false
+	c.setConnectedLocked(false)
 	c.initialized = false
 	c.serverInfo = nil
 	c.serverCapabilities = nil
+	c.protocolVersion = ""
+	c.readDone = nil
+	if c.cancelSession != nil {
+		c.cancelSession()
+	}
 
-	c.logger.Println("Disconnected from MCP server")
+	c.failPending(cause)
 	return err
 }
 
@@ -245,21 +492,72 @@ func (c *Client) GetServerCapabilities() *mcp.ServerCapabilities {
 	return &caps
 }
 
-// ListTools retrieves all available tools from the server
+// ProtocolVersion returns the protocol version negotiated during Initialize,
+// or "" if the client is not initialized
+func (c *Client) ProtocolVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.protocolVersion
+}
+
+// SupportsFeature reports whether the negotiated protocol version includes
+// feature. It returns false before Initialize completes.
+//
+// Example:
+//
+//	if client.SupportsFeature(mcp.FeatureStructuredContent) {
+//		// result.StructuredContent may be set
+//	}
+func (c *Client) SupportsFeature(feature mcp.Feature) bool {
+	return mcp.SupportsFeature(c.ProtocolVersion(), feature)
+}
+
+// ListTools retrieves all available tools from the server, following
+// pagination cursors up to the configured page limit. With
+// ClientConfig.CacheCatalog later calls may be answered from the cache.
 func (c *Client) ListTools(ctx context.Context) ([]mcp.Tool, error) {
-	if !c.IsInitialized() {
-		return nil, fmt.Errorf("client not initialized")
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	c.logger.Println("Listing available tools...")
+	caps := c.GetServerCapabilities()
+	listChanged := caps != nil && caps.Tools != nil && caps.Tools.ListChanged
+
+	return listCached(c, &c.catalog.tools, listChanged, func() ([]mcp.Tool, error) {
+		c.log().Debug("Listing tools")
 
-	response, err := c.sendRequest(ctx, "tools/list", mcp.ListToolsRequest{})
+		var tools []mcp.Tool
+		err := c.paginate(func(cursor string) (string, error) {
+			page, err := c.ListToolsPage(ctx, cursor)
+			if err != nil {
+				return "", err
+			}
+			tools = append(tools, page.Tools...)
+			return page.NextCursor, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		c.log().Debug("Listed tools", slog.Int("count", len(tools)))
+		return tools, nil
+	})
+}
+
+// ListToolsPage retrieves a single page of tools. Pass an empty cursor for the
+// first page and the returned NextCursor for the following ones.
+func (c *Client) ListToolsPage(ctx context.Context, cursor string) (*mcp.ListToolsResponse, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := c.sendRequest(ctx, "tools/list", mcp.ListToolsRequest{Cursor: cursor})
 	if err != nil {
 		return nil, fmt.Errorf("list tools request failed: %w", err)
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("list tools error: %s", response.Error.Message)
+		return nil, fmt.Errorf("list tools error: %w", newMCPError(response.Error))
 	}
 
 	var listResponse mcp.ListToolsResponse
@@ -267,35 +565,59 @@ func (c *Client) ListTools(ctx context.Context) ([]mcp.Tool, error) {
 		return nil, fmt.Errorf("failed to parse list tools response: %w", err)
 	}
 
-	c.logger.Printf("Found %d tools", len(listResponse.Tools))
-	return listResponse.Tools, nil
+	return &listResponse, nil
 }
 
 // CallTool executes a tool on the server
 func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.CallToolResponse, error) {
-	if !c.IsInitialized() {
-		return nil, fmt.Errorf("client not initialized")
+	return c.callTool(ctx, mcp.CallToolRequest{
+		Name:      name,
+		Arguments: arguments,
+	})
+}
+
+// CallToolWithProgress executes a tool on the server and reports progress.
+//
+// A progress token is attached to the request and onProgress is called for
+// every notifications/progress the server sends for it. Servers are not
+// required to send progress, so onProgress may never be called.
+func (c *Client) CallToolWithProgress(ctx context.Context, name string, arguments map[string]interface{}, onProgress func(mcp.ProgressNotification)) (*mcp.CallToolResponse, error) {
+	token := c.registerProgress(onProgress)
+	defer c.unregisterProgress(token)
+
+	return c.callTool(ctx, mcp.CallToolRequest{
+		Name:      name,
+		Arguments: arguments,
+		Meta:      &mcp.RequestMeta{ProgressToken: token},
+	})
+}
+
+// callTool sends a tools/call request
+func (c *Client) callTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResponse, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	// Check connection health before making the call
-	if err := c.CheckConnection(); err != nil {
-		return nil, fmt.Errorf("connection check failed: %w", err)
+	name := request.Name
+	if c.validateArguments {
+		if err := c.ValidateToolArguments(ctx, name, request.Arguments); err != nil {
+			return nil, err
+		}
 	}
 
-	c.logger.Printf("Calling tool: %s", name)
+	c.log().Debug("Calling tool", slog.String("tool", name))
 
-	request := mcp.CallToolRequest{
-		Name:      name,
-		Arguments: arguments,
-	}
+	// Tools known to be idempotent may be retried after a reconnect
+	tool, known := c.cachedTool(name)
+	retry := known && tool.Annotations.IsIdempotent()
 
-	response, err := c.sendRequest(ctx, "tools/call", request)
+	response, err := c.request(ctx, "tools/call", request, retry)
 	if err != nil {
 		return nil, fmt.Errorf("call tool request failed: %w", err)
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("call tool error: %s", response.Error.Message)
+		return nil, fmt.Errorf("call tool error: %w", newMCPError(response.Error))
 	}
 
 	var callResponse mcp.CallToolResponse
@@ -303,25 +625,114 @@ func (c *Client) CallTool(ctx context.Context, name string, arguments map[string
 		return nil, fmt.Errorf("failed to parse call tool response: %w", err)
 	}
 
-	c.logger.Printf("Tool '%s' executed successfully", name)
+	c.log().Debug("Tool executed", slog.String("tool", name), slog.Bool("is_error", callResponse.IsError))
 	return &callResponse, nil
 }
 
-// ListResources retrieves all available resources from the server
+// CallToolInto executes a tool and decodes its structured result into out,
+// which must be a pointer.
+//
+// The result's structuredContent is used when present. Servers speaking a
+// protocol version older than 2025-06-18 cannot send structured content, so
+// as a fallback a result consisting of a single text block holding JSON is
+// decoded instead. If the tool reports an error the returned error wraps
+// ErrToolError. The raw response is returned alongside any decoding error.
+//
+// Example:
+//
+//	var forecast struct {
+//		Temperature float64 `json:"temperature"`
+//		Conditions  string  `json:"conditions"`
+//	}
+//	_, err := client.CallToolInto(ctx, "get_weather", map[string]interface{}{"city": "Paris"}, &forecast)
+func (c *Client) CallToolInto(ctx context.Context, name string, arguments map[string]interface{}, out interface{}) (*mcp.CallToolResponse, error) {
+	result, err := c.CallTool(ctx, name, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.IsError {
+		return result, fmt.Errorf("%w: %s", ErrToolError, toolErrorText(result))
+	}
+
+	if result.StructuredContent != nil {
+		if err := parseResult(result.StructuredContent, out); err != nil {
+			return result, fmt.Errorf("failed to decode structured content of tool %s: %w", name, err)
+		}
+		return result, nil
+	}
+
+	if len(result.Content) == 1 {
+		if text, ok := result.Content[0].AsText(); ok && json.Valid([]byte(text.Text)) {
+			if err := json.Unmarshal([]byte(text.Text), out); err != nil {
+				return result, fmt.Errorf("failed to decode result of tool %s: %w", name, err)
+			}
+			return result, nil
+		}
+	}
+
+	return result, fmt.Errorf("tool %s: %w", name, ErrNoStructuredContent)
+}
+
+// toolErrorText joins the text blocks of a failed tool result
+func toolErrorText(result *mcp.CallToolResponse) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.AsText(); ok && text.Text != "" {
+			parts = append(parts, text.Text)
+		}
+	}
+	if len(parts) == 0 {
+		return "no error details"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ListResources retrieves all available resources from the server, following
+// pagination cursors up to the configured page limit. With
+// ClientConfig.CacheCatalog later calls may be answered from the cache.
 func (c *Client) ListResources(ctx context.Context) ([]mcp.Resource, error) {
-	if !c.IsInitialized() {
-		return nil, fmt.Errorf("client not initialized")
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	c.logger.Println("Listing available resources...")
+	caps := c.GetServerCapabilities()
+	listChanged := caps != nil && caps.Resources != nil && caps.Resources.ListChanged
+
+	return listCached(c, &c.catalog.resources, listChanged, func() ([]mcp.Resource, error) {
+		c.log().Debug("Listing resources")
+
+		var resources []mcp.Resource
+		err := c.paginate(func(cursor string) (string, error) {
+			page, err := c.ListResourcesPage(ctx, cursor)
+			if err != nil {
+				return "", err
+			}
+			resources = append(resources, page.Resources...)
+			return page.NextCursor, nil
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	response, err := c.sendRequest(ctx, "resources/list", mcp.ListResourcesRequest{})
+		c.log().Debug("Listed resources", slog.Int("count", len(resources)))
+		return resources, nil
+	})
+}
+
+// ListResourcesPage retrieves a single page of resources
+func (c *Client) ListResourcesPage(ctx context.Context, cursor string) (*mcp.ListResourcesResponse, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := c.sendRequest(ctx, "resources/list", mcp.ListResourcesRequest{Cursor: cursor})
 	if err != nil {
 		return nil, fmt.Errorf("list resources request failed: %w", err)
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("list resources error: %s", response.Error.Message)
+		return nil, fmt.Errorf("list resources error: %w", newMCPError(response.Error))
 	}
 
 	var listResponse mcp.ListResourcesResponse
@@ -329,25 +740,103 @@ func (c *Client) ListResources(ctx context.Context) ([]mcp.Resource, error) {
 		return nil, fmt.Errorf("failed to parse list resources response: %w", err)
 	}
 
-	c.logger.Printf("Found %d resources", len(listResponse.Resources))
-	return listResponse.Resources, nil
+	return &listResponse, nil
 }
 
-// ListPrompts retrieves all available prompts from the server
+// ListResourceTemplates retrieves all resource templates from the server,
+// following pagination cursors up to the configured page limit
+func (c *Client) ListResourceTemplates(ctx context.Context) ([]mcp.ResourceTemplate, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	c.log().Debug("Listing resource templates")
+
+	var templates []mcp.ResourceTemplate
+	err := c.paginate(func(cursor string) (string, error) {
+		page, err := c.ListResourceTemplatesPage(ctx, cursor)
+		if err != nil {
+			return "", err
+		}
+		templates = append(templates, page.ResourceTemplates...)
+		return page.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.log().Debug("Listed resource templates", slog.Int("count", len(templates)))
+	return templates, nil
+}
+
+// ListResourceTemplatesPage retrieves a single page of resource templates
+func (c *Client) ListResourceTemplatesPage(ctx context.Context, cursor string) (*mcp.ListResourceTemplatesResponse, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := c.sendRequest(ctx, "resources/templates/list", mcp.ListResourceTemplatesRequest{Cursor: cursor})
+	if err != nil {
+		return nil, fmt.Errorf("list resource templates request failed: %w", err)
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("list resource templates error: %w", newMCPError(response.Error))
+	}
+
+	var listResponse mcp.ListResourceTemplatesResponse
+	if err := parseResult(response.Result, &listResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse list resource templates response: %w", err)
+	}
+
+	return &listResponse, nil
+}
+
+// ListPrompts retrieves all available prompts from the server, following
+// pagination cursors up to the configured page limit. With
+// ClientConfig.CacheCatalog later calls may be answered from the cache.
 func (c *Client) ListPrompts(ctx context.Context) ([]mcp.Prompt, error) {
-	if !c.IsInitialized() {
-		return nil, fmt.Errorf("client not initialized")
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	c.logger.Println("Listing available prompts...")
+	caps := c.GetServerCapabilities()
+	listChanged := caps != nil && caps.Prompts != nil && caps.Prompts.ListChanged
 
-	response, err := c.sendRequest(ctx, "prompts/list", mcp.ListPromptsRequest{})
+	return listCached(c, &c.catalog.prompts, listChanged, func() ([]mcp.Prompt, error) {
+		c.log().Debug("Listing prompts")
+
+		var prompts []mcp.Prompt
+		err := c.paginate(func(cursor string) (string, error) {
+			page, err := c.ListPromptsPage(ctx, cursor)
+			if err != nil {
+				return "", err
+			}
+			prompts = append(prompts, page.Prompts...)
+			return page.NextCursor, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		c.log().Debug("Listed prompts", slog.Int("count", len(prompts)))
+		return prompts, nil
+	})
+}
+
+// ListPromptsPage retrieves a single page of prompts
+func (c *Client) ListPromptsPage(ctx context.Context, cursor string) (*mcp.ListPromptsResponse, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := c.sendRequest(ctx, "prompts/list", mcp.ListPromptsRequest{Cursor: cursor})
 	if err != nil {
 		return nil, fmt.Errorf("list prompts request failed: %w", err)
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("list prompts error: %s", response.Error.Message)
+		return nil, fmt.Errorf("list prompts error: %w", newMCPError(response.Error))
 	}
 
 	var listResponse mcp.ListPromptsResponse
@@ -355,17 +844,16 @@ func (c *Client) ListPrompts(ctx context.Context) ([]mcp.Prompt, error) {
 		return nil, fmt.Errorf("failed to parse list prompts response: %w", err)
 	}
 
-	c.logger.Printf("Found %d prompts", len(listResponse.Prompts))
-	return listResponse.Prompts, nil
+	return &listResponse, nil
 }
 
 // GetPrompt retrieves a specific prompt from the server with optional arguments
 func (c *Client) GetPrompt(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.GetPromptResponse, error) {
-	if !c.IsInitialized() {
-		return nil, fmt.Errorf("client not initialized")
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	c.logger.Printf("Getting prompt: %s", name)
+	c.log().Debug("Getting prompt", slog.String("prompt", name))
 
 	request := mcp.GetPromptRequest{
 		Name:      name,
@@ -378,7 +866,7 @@ func (c *Client) GetPrompt(ctx context.Context, name string, arguments map[strin
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("get prompt error: %s", response.Error.Message)
+		return nil, fmt.Errorf("get prompt error: %w", newMCPError(response.Error))
 	}
 
 	var promptResponse mcp.GetPromptResponse
@@ -386,17 +874,17 @@ func (c *Client) GetPrompt(ctx context.Context, name string, arguments map[strin
 		return nil, fmt.Errorf("failed to parse get prompt response: %w", err)
 	}
 
-	c.logger.Printf("Retrieved prompt '%s' with %d messages", name, len(promptResponse.Messages))
+	c.log().Debug("Retrieved prompt", slog.String("prompt", name), slog.Int("count", len(promptResponse.Messages)))
 	return &promptResponse, nil
 }
 
 // ReadResource retrieves the content of a specific resource from the server
 func (c *Client) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResponse, error) {
-	if !c.IsInitialized() {
-		return nil, fmt.Errorf("client not initialized")
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	c.logger.Printf("Reading resource: %s", uri)
+	c.log().Debug("Reading resource", slog.String("uri", uri))
 
 	request := mcp.ReadResourceRequest{
 		URI: uri,
@@ -408,7 +896,7 @@ func (c *Client) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourc
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("read resource error: %s", response.Error.Message)
+		return nil, fmt.Errorf("read resource error: %w", newMCPError(response.Error))
 	}
 
 	var resourceResponse mcp.ReadResourceResponse
@@ -416,110 +904,416 @@ func (c *Client) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourc
 		return nil, fmt.Errorf("failed to parse read resource response: %w", err)
 	}
 
-	c.logger.Printf("Read resource '%s' with %d content items", uri, len(resourceResponse.Contents))
+	c.log().Debug("Read resource", slog.String("uri", uri), slog.Int("count", len(resourceResponse.Contents)))
 	return &resourceResponse, nil
 }
 
-// sendRequest sends a request and waits for the response
+// Complete asks the server for completion values for a prompt argument or a
+// resource template variable.
+//
+// Example:
+//
+//	completion, err := client.Complete(ctx, mcp.NewPromptReference("review"), "branch", "ma")
+//	// completion.Values == []string{"main", "master"}
+func (c *Client) Complete(ctx context.Context, ref mcp.CompletionReference, argName, partialValue string) (*mcp.Completion, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	request := mcp.CompleteRequest{
+		Ref: ref,
+		Argument: mcp.CompletionArgument{
+			Name:  argName,
+			Value: partialValue,
+		},
+	}
+
+	response, err := c.sendRequest(ctx, "completion/complete", request)
+	if err != nil {
+		return nil, fmt.Errorf("complete request failed: %w", err)
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("complete error: %w", newMCPError(response.Error))
+	}
+
+	var completeResponse mcp.CompleteResponse
+	if err := parseResult(response.Result, &completeResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse complete response: %w", err)
+	}
+
+	return &completeResponse.Completion, nil
+}
+
+// ReadResourceTemplate expands an RFC 6570 URI template with vars and reads
+// the resulting resource
+//
+// Example:
+//
+//	content, err := client.ReadResourceTemplate(ctx, "file:///{+path}", map[string]interface{}{
+//		"path": "src/main.go",
+//	})
+func (c *Client) ReadResourceTemplate(ctx context.Context, template string, vars map[string]interface{}) (*mcp.ReadResourceResponse, error) {
+	uri, err := uritemplate.Expand(template, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand resource template %q: %w", template, err)
+	}
+
+	return c.ReadResource(ctx, uri)
+}
+
+// sendRequest sends a request and waits for the response.
+//
+// The response is delivered by the reader goroutine, so any number of
+// requests may be in flight at the same time.
+//
+// If a reconnect policy is set and the connection is lost, the session is
+// restored and idempotent requests are sent again.
 func (c *Client) sendRequest(ctx context.Context, method string, params interface{}) (*mcp.Message, error) {
+	return c.request(ctx, method, params, idempotentMethods[method])
+}
+
+// request sends a request, sending it again after a reconnect if retry is set
+func (c *Client) request(ctx context.Context, method string, params interface{}, retry bool) (*mcp.Message, error) {
+	c.mu.RLock()
+	generation := c.generation
+	c.mu.RUnlock()
+
+	response, err := c.roundTrip(ctx, method, params)
+	if err == nil || method == "initialize" || !c.canReconnect(ctx) || !isConnectionError(err) {
+		return response, err
+	}
+
+	if !retry {
+		// The server may have acted on the request, so only restore the
+		// session for the next one
+		c.startReconnect(generation)
+		return nil, err
+	}
+
+	if reconnectErr := c.awaitReconnect(ctx, generation); reconnectErr != nil {
+		return nil, fmt.Errorf("%w (reconnect failed: %w)", err, reconnectErr)
+	}
+
+	c.log().Info("Retrying request after reconnect", slog.String("method", method))
+	return c.roundTrip(ctx, method, params)
+}
+
+// roundTrip sends a request through the middleware chain and waits for its
+// response
+func (c *Client) roundTrip(ctx context.Context, method string, params interface{}) (*mcp.Message, error) {
 	requestID := atomic.AddInt64(&c.requestID, 1)
 
 	request := mcp.NewRequest(requestID, method, params)
+	response, err := c.outgoing(withDirection(ctx, Outgoing), request)
+	if err == nil && response == nil {
+		return nil, fmt.Errorf("request %s: middleware returned no response", method)
+	}
+	return response, err
+}
+
+// exchange sends a request and waits for its response. It is the innermost
+// handler of the outgoing middleware chain.
+func (c *Client) exchange(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+	requestID, ok := request.ID.(int64)
+	if !ok {
+		return nil, fmt.Errorf("request %s has invalid id %v", request.Method, request.ID)
+	}
+	method := request.Method
+	// A batch must not wait for a request that fails before it is sent
+	defer releaseBatchSlot(ctx)
 
 	// Check if transport is still connected before sending
 	if !c.transport.IsConnected() {
-		return nil, fmt.Errorf("transport disconnected")
+		return nil, fmt.Errorf("transport disconnected: %w", ErrNotConnected)
+	}
+
+	responseChan := make(chan *mcp.Message, 1)
+	c.pendingMu.Lock()
+	if c.readErr != nil {
+		err := c.readErr
+		c.pendingMu.Unlock()
+		return nil, NewTransportError(transportName(c.transport), "failed to receive response", err)
 	}
+	c.pending[requestID] = responseChan
+	c.pendingMu.Unlock()
+
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, requestID)
+		c.pendingMu.Unlock()
+	}()
+
+	if err := c.transmit(ctx, request); err != nil {
+		// A request withdrawn from its batch never reached the server, so
+		// the connection is fine
+		if errors.Is(err, errWithdrawn) {
+			if ctx.Err() == context.Canceled {
+				return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+			}
+			return nil, fmt.Errorf("request %s: %w (%w)", method, ErrTimeout, ctx.Err())
+		}
+
+		// A request that cannot be encoded was never written
+		if isEncodingError(err) {
+			return nil, fmt.Errorf("failed to encode request %s: %w", method, err)
+		}
 
-	if err := c.transport.Send(request); err != nil {
 		// Mark client as disconnected if send fails
 		c.mu.Lock()
-		c.connected = false
+		c.setConnectedLocked(false)
 		c.initialized = false
 		c.mu.Unlock()
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, NewTransportError(transportName(c.transport), "failed to send request",
+			fmt.Errorf("%w: %w", ErrConnectionClosed, err))
 	}
 
 	// Wait for response with timeout
 	responseCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
+	select {
+	case response, ok := <-responseChan:
+		if !ok {
+			c.pendingMu.Lock()
+			err := c.readErr
+			c.pendingMu.Unlock()
+			return nil, NewTransportError(transportName(c.transport), "failed to receive response", err)
+		}
+		return response, nil
+	case <-responseCtx.Done():
+		if ctx.Err() == context.Canceled {
+			c.log().Debug("Request cancelled", slog.Int64("request_id", requestID), slog.String("method", method))
+			c.cancelRequest(method, requestID, "request cancelled by client")
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
+		c.log().Warn("Request timed out", slog.Int64("request_id", requestID), slog.String("method", method), slog.Duration("timeout", c.timeout))
+		c.cancelRequest(method, requestID, "request timeout")
+		// Wrap the context error too, so context.DeadlineExceeded matches
+		return nil, fmt.Errorf("request %s: %w (%w)", method, ErrTimeout, responseCtx.Err())
+	}
+}
+
+// cancelRequest tells the server to stop working on a request the client has
+// given up on. The initialize request cannot be cancelled.
+func (c *Client) cancelRequest(method string, requestID int64, reason string) {
+	if method == "initialize" {
+		return
+	}
+
+	c.traceCancellation(requestID, reason)
+	notification := mcp.NewNotification(mcp.NotificationCancelled, mcp.CancelledNotification{
+		RequestID: requestID,
+		Reason:    reason,
+	})
+	if err := c.send(notification); err != nil {
+		c.log().Warn("Failed to send cancellation", slog.Int64("request_id", requestID), slog.String("method", method), slog.Any("error", err))
+	}
+}
+
+// log returns the logger for the current session
+func (c *Client) log() *slog.Logger {
+	return c.logger.Load()
+}
+
+// send writes a single message to the transport
+func (c *Client) send(message *mcp.Message) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.transport.Send(message)
+}
+
+// readLoop owns transport.Receive for the lifetime of a connection. Responses
+// are routed to the goroutine waiting in sendRequest, requests of a batch
+// frame are answered together by handleBatch, everything else is queued on
+// messages for notifyLoop.
+func (c *Client) readLoop(ctx context.Context, done chan struct{}, messages chan<- *mcp.Message, generation int64) {
+	defer close(done)
+	defer close(messages)
+
 	for {
-		select {
-		case <-responseCtx.Done():
-			c.logger.Printf("Request %d timed out", requestID)
-			return nil, fmt.Errorf("request timeout")
-		default:
-			response, err := c.transport.Receive()
-			if err != nil {
-				// Mark client as disconnected if receive fails
-				c.mu.Lock()
-				c.connected = false
+		frame, err := c.receiveFrame()
+		if err != nil {
+			if errors.Is(err, transport.ErrReceiveTimeout) {
+				continue
+			}
+
+			// Mark client as disconnected unless a newer connection took
+			// over. Pending requests are failed under c.mu so that a new
+			// connection cannot be failed by mistake.
+			c.mu.Lock()
+			current := c.readDone == done
+			if current {
+				c.log().Warn("Connection lost", slog.Any("error", err))
+				c.setConnectedLocked(false)
 				c.initialized = false
-				c.mu.Unlock()
-				return nil, fmt.Errorf("failed to receive response: %w", err)
+				c.readDone = nil
+				c.cancelSession()
+				c.failPending(fmt.Errorf("%w: %w", ErrConnectionClosed, err))
 			}
+			reconnect := current && c.reconnectPolicy != nil && !c.closed && c.clientInfo != nil
+			c.mu.Unlock()
 
-			// Check if this is the response we're waiting for
-			// Handle different ID types (JSON unmarshaling might convert int64 to float64)
-			if c.isMatchingID(response.ID, requestID) {
-				return response, nil
+			// Restore the session in the background, so subscriptions and
+			// notifications resume without waiting for the next request
+			if reconnect {
+				c.startReconnect(generation)
 			}
+			return
+		}
 
-			// Handle notifications or other messages
-			c.handleMessage(response)
+		var requests []*mcp.Message
+		for _, message := range frame {
+			switch {
+			case message.Method == "" && message.ID != nil:
+				c.dispatchResponse(message)
+			case message.ID != nil && len(frame) > 1:
+				// Requests of a batch are answered together
+				requests = append(requests, message)
+			default:
+				if message.ID == nil {
+					c.traceNotification(message)
+				}
+				messages <- message
+			}
+		}
+		if len(requests) > 0 {
+			go c.handleBatch(ctx, requests)
 		}
 	}
 }
 
-// handleMessage processes incoming messages (notifications, etc.)
-func (c *Client) handleMessage(message *mcp.Message) {
-	if message.Method != "" && message.ID == nil {
+// receiveFrame receives the messages of the next frame, keeping the messages
+// of a batch together if the transport can
+func (c *Client) receiveFrame() ([]*mcp.Message, error) {
+	if receiver, ok := c.transport.(transport.FrameReceiver); ok {
+		return receiver.ReceiveFrame()
+	}
+
+	message, err := c.transport.Receive()
+	if err != nil {
+		return nil, err
+	}
+	return []*mcp.Message{message}, nil
+}
+
+// dispatchResponse delivers a response to the request waiting for it
+func (c *Client) dispatchResponse(message *mcp.Message) {
+	id, ok := parseID(message.ID)
+	if !ok {
+		c.log().Warn("Received response with unsupported ID", slog.Any("request_id", message.ID))
+		return
+	}
+
+	c.pendingMu.Lock()
+	responseChan, ok := c.pending[id]
+	if ok {
+		delete(c.pending, id)
+	}
+	c.pendingMu.Unlock()
+
+	if !ok {
+		c.log().Debug("Received response for unknown request", slog.Int64("request_id", id))
+		return
+	}
+
+	responseChan <- message
+}
+
+// failPending wakes every in-flight request with the given error
+func (c *Client) failPending(err error) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	c.readErr = err
+	for id, responseChan := range c.pending {
+		close(responseChan)
+		delete(c.pending, id)
+	}
+}
+
+// handleMessage processes incoming messages (notifications and server requests)
+func (c *Client) handleMessage(ctx context.Context, message *mcp.Message) {
+	if message.Method == "" {
+		return
+	}
+
+	if message.ID == nil {
 		// This is a notification
-		c.logger.Printf("Received notification: %s", message.Method)
+		c.handleNotification(message)
+		return
+	}
+
+	// Server-initiated request; answer it without holding up notifications
+	go c.handleRequest(ctx, message)
+}
+
+// paginate calls fetch with successive cursors until the server stops
+// returning one or the page limit is reached
+func (c *Client) paginate(fetch func(cursor string) (string, error)) error {
+	cursor := ""
+	for page := 0; page < c.maxPages; page++ {
+		next, err := fetch(cursor)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
 	}
+	return fmt.Errorf("%w: stopped after %d pages", ErrPageLimitExceeded, c.maxPages)
 }
 
 // parseResult parses a response result into the target structure
 func parseResult(result interface{}, target interface{}) error {
 	if result == nil {
-		return fmt.Errorf("result is nil")
+		return fmt.Errorf("%w: result is nil", ErrInvalidResponse)
 	}
 
 	// Convert result to JSON and back to properly unmarshal into target
 	jsonData, err := json.Marshal(result)
 	if err != nil {
-		return fmt.Errorf("failed to marshal result: %w", err)
+		return fmt.Errorf("%w: failed to marshal result: %v", ErrInvalidResponse, err)
 	}
 
 	if err := json.Unmarshal(jsonData, target); err != nil {
-		return fmt.Errorf("failed to unmarshal result: %w", err)
+		return fmt.Errorf("%w: failed to unmarshal result: %v", ErrInvalidResponse, err)
 	}
 
 	return nil
 }
 
-// isMatchingID compares request IDs, handling JSON unmarshaling type conversions
-func (c *Client) isMatchingID(responseID interface{}, requestID int64) bool {
-	if responseID == nil {
-		return false
-	}
-
-	switch id := responseID.(type) {
+// parseID converts a JSON-RPC ID to the int64 form used for requests, handling
+// JSON unmarshaling type conversions
+func parseID(id interface{}) (int64, bool) {
+	switch id := id.(type) {
 	case int64:
-		return id == requestID
+		return id, true
 	case float64:
-		return int64(id) == requestID
+		return int64(id), true
 	case int:
-		return int64(id) == requestID
+		return int64(id), true
 	case string:
 		// Try to parse string as int
 		if parsedID, err := strconv.ParseInt(id, 10, 64); err == nil {
-			return parsedID == requestID
+			return parsedID, true
 		}
 	}
 
-	return false
+	return 0, false
+}
+
+// setConnectedLocked updates the connection state and the connection gauge.
+// c.mu must be held.
+func (c *Client) setConnectedLocked(connected bool) {
+	if c.connected == connected {
+		return
+	}
+	c.connected = connected
+	if c.metrics != nil {
+		c.metrics.connectionChanged(connected)
+	}
 }
 
 // CheckConnection verifies the transport is still connected and updates client state
@@ -528,9 +1322,9 @@ func (c *Client) CheckConnection() error {
 	defer c.mu.Unlock()
 
 	if !c.transport.IsConnected() {
-		c.connected = false
+		c.setConnectedLocked(false)
 		c.initialized = false
-		return fmt.Errorf("transport disconnected")
+		return fmt.Errorf("transport disconnected: %w", ErrNotConnected)
 	}
 
 	return nil
@@ -1,6 +1,15 @@
 package client
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/jsonschema"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
+)
 
 // Library-friendly error types for better error handling in third-party applications
 
@@ -19,9 +28,41 @@ var (
 
 	// ErrInvalidResponse indicates the server returned an invalid response
 	ErrInvalidResponse = errors.New("invalid server response")
+
+	// ErrNotSupported indicates the server did not advertise the capability
+	// required for an operation
+	ErrNotSupported = errors.New("not supported by server")
+
+	// ErrPageLimitExceeded indicates a paginated list did not end within the
+	// configured maximum number of pages
+	ErrPageLimitExceeded = errors.New("page limit exceeded")
+
+	// ErrToolError indicates a tool call completed but the tool reported an
+	// error (isError in the result)
+	ErrToolError = errors.New("tool returned an error")
+
+	// ErrNoStructuredContent indicates a tool result had neither
+	// structuredContent nor a JSON text block to decode
+	ErrNoStructuredContent = errors.New("tool result has no structured content")
+
+	// ErrNotJSON indicates a resource read with ReadResourceAs is not JSON
+	ErrNotJSON = errors.New("resource is not JSON")
+
+	// ErrReconnectFailed indicates the reconnect policy ran out of attempts
+	// to restore a lost session
+	ErrReconnectFailed = errors.New("reconnect failed")
+
+	// ErrUnknownTool indicates a tool is not in the server's tool list
+	ErrUnknownTool = errors.New("unknown tool")
 )
 
-// MCPError represents an error from the MCP server
+// MCPError represents an error response from the MCP server. Client methods
+// wrap it, so use errors.As to inspect the code and data:
+//
+//	var mcpErr *client.MCPError
+//	if errors.As(err, &mcpErr) && mcpErr.Code == mcp.ErrorCodeInvalidParams {
+//		// ...
+//	}
 type MCPError struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
@@ -32,15 +73,54 @@ func (e *MCPError) Error() string {
 	return e.Message
 }
 
-// IsErrorCode checks if an error is an MCPError with a specific code
+// newMCPError converts the error of a JSON-RPC response into an *MCPError
+func newMCPError(info *mcp.ErrorInfo) *MCPError {
+	return &MCPError{
+		Code:    info.Code,
+		Message: info.Message,
+		Data:    info.Data,
+	}
+}
+
+// IsErrorCode checks if an error is, or wraps, an MCPError with a specific code
 func IsErrorCode(err error, code int) bool {
-	if mcpErr, ok := err.(*MCPError); ok {
+	var mcpErr *MCPError
+	if errors.As(err, &mcpErr) {
 		return mcpErr.Code == code
 	}
 	return false
 }
 
-// TransportError represents a transport-level error
+// UnsupportedProtocolVersionError is returned by Initialize when the server
+// answers with a protocol version this client cannot speak
+type UnsupportedProtocolVersionError struct {
+	Requested string   // Version offered by the client
+	Received  string   // Version chosen by the server
+	Supported []string // Versions the client supports
+}
+
+func (e *UnsupportedProtocolVersionError) Error() string {
+	return fmt.Sprintf("server selected unsupported protocol version %q (requested %s, supported: %s)",
+		e.Received, e.Requested, strings.Join(e.Supported, ", "))
+}
+
+// ValidationError is returned when tool arguments do not match the tool's
+// input schema. Nothing is sent to the server in that case.
+type ValidationError struct {
+	Tool       string
+	Violations []jsonschema.Violation
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		problems[i] = violation.String()
+	}
+	return fmt.Sprintf("invalid arguments for tool %s: %s", e.Tool, strings.Join(problems, "; "))
+}
+
+// TransportError represents a transport-level error. Errors caused by a lost
+// connection also wrap ErrConnectionClosed.
 type TransportError struct {
 	Type    string // "tcp", "stdio", "websocket", etc.
 	Message string
@@ -66,3 +146,26 @@ func NewTransportError(transportType, message string, cause error) *TransportErr
 		Cause:   cause,
 	}
 }
+
+// isEncodingError reports whether a send failed because the message could
+// not be encoded to JSON, which leaves the connection intact
+func isEncodingError(err error) bool {
+	var unsupportedType *json.UnsupportedTypeError
+	var unsupportedValue *json.UnsupportedValueError
+	var marshaler *json.MarshalerError
+	return errors.As(err, &unsupportedType) || errors.As(err, &unsupportedValue) || errors.As(err, &marshaler)
+}
+
+// transportName returns the name used in TransportError.Type for t
+func transportName(t transport.Transport) string {
+	switch t.(type) {
+	case *transport.TCPTransport:
+		return "tcp"
+	case *transport.StdioTransport:
+		return "stdio"
+	case *transport.WebSocketTransport:
+		return "websocket"
+	default:
+		return fmt.Sprintf("%T", t)
+	}
+}
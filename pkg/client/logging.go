@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// LogSink receives the log messages a server sends with notifications/message.
+//
+// Log is called on the notification goroutine, so it should not block for long.
+type LogSink interface {
+	Log(message mcp.LoggingMessageNotification)
+}
+
+// LogSinkFunc adapts an ordinary function to the LogSink interface
+type LogSinkFunc func(message mcp.LoggingMessageNotification)
+
+// Log calls f(message)
+func (f LogSinkFunc) Log(message mcp.LoggingMessageNotification) {
+	f(message)
+}
+
+// writerLogSink writes one line per server log message
+type writerLogSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterLogSink returns a LogSink that writes each message to w as a
+// single line in the form "[level] logger: data". Data that is not a string
+// is written as JSON.
+func NewWriterLogSink(w io.Writer) LogSink {
+	return &writerLogSink{w: w}
+}
+
+func (s *writerLogSink) Log(message mcp.LoggingMessageNotification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintln(s.w, FormatLogMessage(message))
+}
+
+// FormatLogMessage renders a server log message as "[level] logger: data"
+func FormatLogMessage(message mcp.LoggingMessageNotification) string {
+	var data string
+	switch v := message.Data.(type) {
+	case string:
+		data = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			data = fmt.Sprint(v)
+		} else {
+			data = string(encoded)
+		}
+	}
+
+	if message.Logger != "" {
+		return fmt.Sprintf("[%s] %s: %s", message.Level, message.Logger, data)
+	}
+	return fmt.Sprintf("[%s] %s", message.Level, data)
+}
+
+// SetLogSink sets the sink that receives server log messages. Passing nil
+// stops delivery; handlers registered with OnLogMessage are unaffected.
+func (c *Client) SetLogSink(sink LogSink) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.logSink = sink
+}
+
+// SetLogLevel asks the server to send log messages at or above level.
+//
+// The level is remembered and sent again every time Initialize completes.
+// It returns an error wrapping ErrNotSupported if the server does not
+// advertise the logging capability.
+//
+// Example:
+//
+//	client.SetLogSink(client.NewWriterLogSink(os.Stderr))
+//	err := client.SetLogLevel(ctx, mcp.LoggingLevelWarning)
+func (c *Client) SetLogLevel(ctx context.Context, level mcp.LoggingLevel) error {
+	if err := c.ensureSession(ctx); err != nil {
+		return err
+	}
+
+	if _, err := mcp.ParseLoggingLevel(string(level)); err != nil {
+		return err
+	}
+
+	if caps := c.GetServerCapabilities(); caps == nil || caps.Logging == nil {
+		return fmt.Errorf("server logging: %w", ErrNotSupported)
+	}
+
+	if err := c.setLogLevel(ctx, level); err != nil {
+		return err
+	}
+
+	c.handlersMu.Lock()
+	c.logLevel = level
+	c.handlersMu.Unlock()
+
+	return nil
+}
+
+// LogLevel returns the level last set with SetLogLevel, or "" if none was set
+func (c *Client) LogLevel() mcp.LoggingLevel {
+	c.handlersMu.RLock()
+	defer c.handlersMu.RUnlock()
+	return c.logLevel
+}
+
+// setLogLevel sends a single logging/setLevel request
+func (c *Client) setLogLevel(ctx context.Context, level mcp.LoggingLevel) error {
+	c.log().Debug("Setting server log level", slog.String("level", string(level)))
+
+	response, err := c.sendRequest(ctx, "logging/setLevel", mcp.SetLevelRequest{Level: level})
+	if err != nil {
+		return fmt.Errorf("set log level request failed: %w", err)
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("set log level error: %w", newMCPError(response.Error))
+	}
+
+	return nil
+}
+
+// restoreLogLevel re-sends the log level after a new session is initialized
+func (c *Client) restoreLogLevel(ctx context.Context) {
+	level := c.LogLevel()
+	if level == "" {
+		return
+	}
+
+	if caps := c.GetServerCapabilities(); caps == nil || caps.Logging == nil {
+		c.log().Warn("Server no longer supports logging, not restoring log level")
+		return
+	}
+
+	if err := c.setLogLevel(ctx, level); err != nil {
+		c.log().Warn("Failed to restore log level", slog.String("level", string(level)), slog.Any("error", err))
+	}
+}
+
+// dispatchLogMessage delivers a notifications/message to the log sink
+func (c *Client) dispatchLogMessage(notification *mcp.Message) bool {
+	c.handlersMu.RLock()
+	sink := c.logSink
+	c.handlersMu.RUnlock()
+
+	if sink == nil {
+		return false
+	}
+
+	var params mcp.LoggingMessageNotification
+	if err := parseResult(notification.Params, &params); err != nil {
+		c.log().Warn("Invalid notification", slog.String("method", notification.Method), slog.Any("error", err))
+		return false
+	}
+
+	sink.Log(params)
+	return true
+}
@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// Request outcomes counted by Metrics
+const (
+	// OutcomeOK is a request answered with a result
+	OutcomeOK = "ok"
+	// OutcomeError is a request answered with a JSON-RPC error
+	OutcomeError = "error"
+	// OutcomeTimeout is a request the server did not answer in time
+	OutcomeTimeout = "timeout"
+	// OutcomeCancelled is a request cancelled by the caller
+	OutcomeCancelled = "cancelled"
+	// OutcomeFailed is a request that could not be sent or lost its connection
+	OutcomeFailed = "failed"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the request latency histogram
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Metrics counts the requests a client sends, how long they take and how they
+// end, along with the number of requests in flight and open connections.
+//
+// Pass it in ClientConfig.Metrics. One Metrics may be shared by several
+// clients; their numbers are added up. Requests the server sends to the
+// client are not counted. Retries after a reconnect count as separate
+// requests.
+//
+// Example:
+//
+//	metrics := client.NewMetrics()
+//	http.Handle("/metrics", metrics.Handler())
+//	c := client.NewClient(transport, client.ClientConfig{Metrics: metrics})
+type Metrics struct {
+	mu          sync.Mutex
+	buckets     []time.Duration
+	methods     map[string]*methodCounters
+	inFlight    int64
+	connections int64
+}
+
+// methodCounters holds the numbers of one method
+type methodCounters struct {
+	outcomes map[string]int64
+	// buckets counts requests per histogram bucket, not cumulatively; the
+	// last entry counts requests slower than every bound
+	buckets []int64
+	sum     time.Duration
+	max     time.Duration
+}
+
+// NewMetrics creates an empty metrics collector using DefaultLatencyBuckets
+func NewMetrics() *Metrics {
+	return &Metrics{
+		buckets: append([]time.Duration(nil), DefaultLatencyBuckets...),
+		methods: make(map[string]*methodCounters),
+	}
+}
+
+// MetricsSnapshot is a copy of the metrics at one point in time
+type MetricsSnapshot struct {
+	// Methods holds per-method numbers, sorted by method
+	Methods     []MethodMetrics
+	InFlight    int64
+	Connections int64
+}
+
+// MethodMetrics holds the numbers of one request method
+type MethodMetrics struct {
+	Method   string
+	Requests int64
+	// Outcomes counts requests by outcome, such as OutcomeOK
+	Outcomes map[string]int64
+	Latency  LatencyHistogram
+}
+
+// Errors returns the number of requests that did not end with OutcomeOK
+func (m MethodMetrics) Errors() int64 {
+	return m.Requests - m.Outcomes[OutcomeOK]
+}
+
+// LatencyHistogram describes how long requests took
+type LatencyHistogram struct {
+	// Buckets hold cumulative counts: the number of requests that took at
+	// most UpperBound
+	Buckets []LatencyBucket
+	Count   int64
+	Sum     time.Duration
+	Max     time.Duration
+}
+
+// LatencyBucket is one bucket of a LatencyHistogram
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// Mean returns the average latency, or zero if there were no requests
+func (h LatencyHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Snapshot returns a copy of the current metrics
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := MetricsSnapshot{
+		InFlight:    m.inFlight,
+		Connections: m.connections,
+	}
+	for method, counters := range m.methods {
+		stats := MethodMetrics{
+			Method:   method,
+			Outcomes: make(map[string]int64, len(counters.outcomes)),
+			Latency:  LatencyHistogram{Sum: counters.sum, Max: counters.max},
+		}
+		for outcome, count := range counters.outcomes {
+			stats.Outcomes[outcome] = count
+			stats.Requests += count
+		}
+
+		var cumulative int64
+		for i, bound := range m.buckets {
+			cumulative += counters.buckets[i]
+			stats.Latency.Buckets = append(stats.Latency.Buckets, LatencyBucket{UpperBound: bound, Count: cumulative})
+		}
+		stats.Latency.Count = cumulative + counters.buckets[len(m.buckets)]
+
+		snapshot.Methods = append(snapshot.Methods, stats)
+	}
+	sort.Slice(snapshot.Methods, func(i, j int) bool {
+		return snapshot.Methods[i].Method < snapshot.Methods[j].Method
+	})
+	return snapshot
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snapshot := m.Snapshot()
+
+	var b strings.Builder
+	b.WriteString("# HELP mcp_client_requests_total Requests sent to MCP servers by method and outcome.\n")
+	b.WriteString("# TYPE mcp_client_requests_total counter\n")
+	for _, method := range snapshot.Methods {
+		outcomes := make([]string, 0, len(method.Outcomes))
+		for outcome := range method.Outcomes {
+			outcomes = append(outcomes, outcome)
+		}
+		sort.Strings(outcomes)
+		for _, outcome := range outcomes {
+			fmt.Fprintf(&b, "mcp_client_requests_total{method=%s,outcome=%s} %d\n",
+				quoteLabel(method.Method), quoteLabel(outcome), method.Outcomes[outcome])
+		}
+	}
+
+	b.WriteString("# HELP mcp_client_request_duration_seconds Latency of requests sent to MCP servers.\n")
+	b.WriteString("# TYPE mcp_client_request_duration_seconds histogram\n")
+	for _, method := range snapshot.Methods {
+		label := quoteLabel(method.Method)
+		for _, bucket := range method.Latency.Buckets {
+			fmt.Fprintf(&b, "mcp_client_request_duration_seconds_bucket{method=%s,le=\"%s\"} %d\n",
+				label, formatSeconds(bucket.UpperBound), bucket.Count)
+		}
+		fmt.Fprintf(&b, "mcp_client_request_duration_seconds_bucket{method=%s,le=\"+Inf\"} %d\n", label, method.Latency.Count)
+		fmt.Fprintf(&b, "mcp_client_request_duration_seconds_sum{method=%s} %s\n", label, formatSeconds(method.Latency.Sum))
+		fmt.Fprintf(&b, "mcp_client_request_duration_seconds_count{method=%s} %d\n", label, method.Latency.Count)
+	}
+
+	b.WriteString("# HELP mcp_client_requests_in_flight Requests waiting for a response.\n")
+	b.WriteString("# TYPE mcp_client_requests_in_flight gauge\n")
+	fmt.Fprintf(&b, "mcp_client_requests_in_flight %d\n", snapshot.InFlight)
+
+	b.WriteString("# HELP mcp_client_connections Open connections to MCP servers.\n")
+	b.WriteString("# TYPE mcp_client_connections gauge\n")
+	fmt.Fprintf(&b, "mcp_client_connections %d\n", snapshot.Connections)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler returns an HTTP handler serving the metrics in the Prometheus text
+// exposition format. The response is rendered before it is written, so the
+// only failure is a write to a scraper that went away; it is logged with
+// slog.Default.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := m.WritePrometheus(w); err != nil {
+			slog.Default().Warn("Failed to write metrics", slog.String("remote_addr", r.RemoteAddr), slog.Any("error", err))
+		}
+	})
+}
+
+// middleware records outgoing requests. It runs innermost, so the latency
+// covers the exchange with the server but not other middleware.
+func (m *Metrics) middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			if RequestDirection(ctx) != Outgoing {
+				return next(ctx, request)
+			}
+
+			m.mu.Lock()
+			m.inFlight++
+			m.mu.Unlock()
+
+			start := time.Now()
+			response, err := next(ctx, request)
+			m.observe(request.Method, requestOutcome(response, err), time.Since(start))
+			return response, err
+		}
+	}
+}
+
+// observe records a finished request
+func (m *Metrics) observe(method, outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight--
+
+	counters, ok := m.methods[method]
+	if !ok {
+		counters = &methodCounters{
+			outcomes: make(map[string]int64),
+			buckets:  make([]int64, len(m.buckets)+1),
+		}
+		m.methods[method] = counters
+	}
+
+	counters.outcomes[outcome]++
+	counters.sum += duration
+	if duration > counters.max {
+		counters.max = duration
+	}
+	bucket := sort.Search(len(m.buckets), func(i int) bool { return duration <= m.buckets[i] })
+	counters.buckets[bucket]++
+}
+
+// connectionChanged updates the connection gauge
+func (m *Metrics) connectionChanged(connected bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if connected {
+		m.connections++
+	} else {
+		m.connections--
+	}
+}
+
+// requestOutcome classifies how a request ended
+func requestOutcome(response *mcp.Message, err error) string {
+	switch {
+	case err == nil && response != nil && response.Error != nil:
+		return OutcomeError
+	case err == nil:
+		return OutcomeOK
+	case errors.Is(err, ErrTimeout):
+		return OutcomeTimeout
+	case errors.Is(err, context.Canceled):
+		return OutcomeCancelled
+	default:
+		return OutcomeFailed
+	}
+}
+
+// quoteLabel quotes a Prometheus label value
+func quoteLabel(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return `"` + value + `"`
+}
+
+// formatSeconds formats a duration as seconds for Prometheus
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// Handler sends or answers a single JSON-RPC request and returns the
+// response message. A response carrying a JSON-RPC error is returned as a
+// message, not as an error; the error is reserved for failures to get a
+// response at all.
+type Handler func(ctx context.Context, request *mcp.Message) (*mcp.Message, error)
+
+// Middleware wraps a Handler to observe or change requests and responses.
+//
+// Middleware runs for requests the client sends and for requests the server
+// sends to the client; use RequestDirection to tell them apart. Outgoing
+// requests pass through the chain again when they are retried after a
+// reconnect.
+//
+// Example:
+//
+//	auth := func(next client.Handler) client.Handler {
+//		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+//			if client.RequestDirection(ctx) == client.Outgoing {
+//				if err := request.SetMeta("authorization", token); err != nil {
+//					return nil, err
+//				}
+//			}
+//			return next(ctx, request)
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+// Chain combines middlewares into one. The first middleware is the outermost,
+// so it sees a request first and its response last.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// Direction tells whether a request was sent by the client or by the server
+type Direction int
+
+const (
+	// Outgoing requests are sent by the client to the server
+	Outgoing Direction = iota
+	// Incoming requests are sent by the server to the client
+	Incoming
+)
+
+func (d Direction) String() string {
+	if d == Incoming {
+		return "incoming"
+	}
+	return "outgoing"
+}
+
+type directionKey struct{}
+
+// RequestDirection returns the direction of the request a middleware is
+// handling
+func RequestDirection(ctx context.Context) Direction {
+	direction, _ := ctx.Value(directionKey{}).(Direction)
+	return direction
+}
+
+func withDirection(ctx context.Context, direction Direction) context.Context {
+	return context.WithValue(ctx, directionKey{}, direction)
+}
+
+// ResponseError returns the error of a handler call: err if the request
+// failed, the JSON-RPC error if the response carries one, or nil
+func ResponseError(response *mcp.Message, err error) error {
+	if err != nil {
+		return err
+	}
+	if response != nil && response.Error != nil {
+		return response.Error
+	}
+	return nil
+}
+
+// LoggingMiddleware logs every request with its direction, request_id,
+// method, duration and, if it failed, error
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return TimingMiddleware(func(ctx context.Context, request *mcp.Message, duration time.Duration, err error) {
+		attrs := []slog.Attr{
+			slog.String("direction", RequestDirection(ctx).String()),
+			slog.Any("request_id", request.ID),
+			slog.String("method", request.Method),
+			slog.Duration("duration", duration),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+			logger.LogAttrs(ctx, slog.LevelWarn, "Request failed", attrs...)
+			return
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "Request completed", attrs...)
+	})
+}
+
+// TimingMiddleware calls record after every request with how long it took and
+// its error, as returned by ResponseError
+func TimingMiddleware(record func(ctx context.Context, request *mcp.Message, duration time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			start := time.Now()
+			response, err := next(ctx, request)
+			record(ctx, request, time.Since(start), ResponseError(response, err))
+			return response, err
+		}
+	}
+}
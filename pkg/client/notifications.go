@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// NotificationHandler is called for every notification received with the
+// method it was registered for.
+//
+// Handlers run on a dedicated goroutine in the order notifications arrive, so
+// they may safely call back into the client. A slow handler delays delivery of
+// later notifications.
+type NotificationHandler func(notification *mcp.Message)
+
+// OnNotification registers a handler for server notifications with the given
+// method. Several handlers may be registered for the same method; they are
+// called in registration order.
+//
+// Example:
+//
+//	client.OnNotification("notifications/tools/list_changed", func(n *mcp.Message) {
+//		tools, _ := client.ListTools(ctx)
+//		// ...
+//	})
+func (c *Client) OnNotification(method string, handler NotificationHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.notificationHandlers[method] = append(c.notificationHandlers[method], handler)
+}
+
+// OnToolsListChanged registers a handler called when the server's tool list changes
+func (c *Client) OnToolsListChanged(handler func()) {
+	c.OnNotification(mcp.NotificationToolsListChanged, func(*mcp.Message) { handler() })
+}
+
+// OnResourcesListChanged registers a handler called when the server's resource list changes
+func (c *Client) OnResourcesListChanged(handler func()) {
+	c.OnNotification(mcp.NotificationResourcesListChanged, func(*mcp.Message) { handler() })
+}
+
+// OnPromptsListChanged registers a handler called when the server's prompt list changes
+func (c *Client) OnPromptsListChanged(handler func()) {
+	c.OnNotification(mcp.NotificationPromptsListChanged, func(*mcp.Message) { handler() })
+}
+
+// OnResourceUpdated registers a handler called when a subscribed resource is updated
+func (c *Client) OnResourceUpdated(handler func(mcp.ResourceUpdatedNotification)) {
+	c.OnNotification(mcp.NotificationResourcesUpdated, func(n *mcp.Message) {
+		var params mcp.ResourceUpdatedNotification
+		if err := parseResult(n.Params, &params); err != nil {
+			c.log().Warn("Invalid notification", slog.String("method", n.Method), slog.Any("error", err))
+			return
+		}
+		handler(params)
+	})
+}
+
+// OnLogMessage registers a handler called for log messages sent by the server
+func (c *Client) OnLogMessage(handler func(mcp.LoggingMessageNotification)) {
+	c.OnNotification(mcp.NotificationMessage, func(n *mcp.Message) {
+		var params mcp.LoggingMessageNotification
+		if err := parseResult(n.Params, &params); err != nil {
+			c.log().Warn("Invalid notification", slog.String("method", n.Method), slog.Any("error", err))
+			return
+		}
+		handler(params)
+	})
+}
+
+// OnProgress registers a handler called for progress notifications
+func (c *Client) OnProgress(handler func(mcp.ProgressNotification)) {
+	c.OnNotification(mcp.NotificationProgress, func(n *mcp.Message) {
+		var params mcp.ProgressNotification
+		if err := parseResult(n.Params, &params); err != nil {
+			c.log().Warn("Invalid notification", slog.String("method", n.Method), slog.Any("error", err))
+			return
+		}
+		handler(params)
+	})
+}
+
+// notifyLoop delivers messages queued by the reader goroutine until the
+// connection closes
+func (c *Client) notifyLoop(ctx context.Context, messages <-chan *mcp.Message) {
+	for message := range messages {
+		c.handleMessage(ctx, message)
+	}
+}
+
+// registerProgress allocates a progress token routed to onProgress
+func (c *Client) registerProgress(onProgress func(mcp.ProgressNotification)) string {
+	token := fmt.Sprintf("progress-%d", atomic.AddInt64(&c.progressToken, 1))
+
+	c.handlersMu.Lock()
+	c.progressHandlers[token] = onProgress
+	c.handlersMu.Unlock()
+
+	return token
+}
+
+// unregisterProgress stops routing progress for token
+func (c *Client) unregisterProgress(token string) {
+	c.handlersMu.Lock()
+	delete(c.progressHandlers, token)
+	c.handlersMu.Unlock()
+}
+
+// dispatchProgress routes a progress notification to the request it belongs to
+func (c *Client) dispatchProgress(notification *mcp.Message) bool {
+	var params mcp.ProgressNotification
+	if err := parseResult(notification.Params, &params); err != nil {
+		return false
+	}
+
+	c.handlersMu.RLock()
+	onProgress, ok := c.progressHandlers[fmt.Sprint(params.ProgressToken)]
+	c.handlersMu.RUnlock()
+
+	if ok && onProgress != nil {
+		onProgress(params)
+	}
+	return ok
+}
+
+// handleNotification runs the handlers registered for a notification
+func (c *Client) handleNotification(notification *mcp.Message) {
+	handled := false
+	switch notification.Method {
+	case mcp.NotificationProgress:
+		handled = c.dispatchProgress(notification)
+	case mcp.NotificationResourcesUpdated:
+		handled = c.dispatchResourceUpdated(notification)
+	case mcp.NotificationMessage:
+		handled = c.dispatchLogMessage(notification)
+	case mcp.NotificationToolsListChanged, mcp.NotificationResourcesListChanged, mcp.NotificationPromptsListChanged:
+		handled = c.dispatchListChanged(notification)
+	}
+
+	c.handlersMu.RLock()
+	handlers := c.notificationHandlers[notification.Method]
+	c.handlersMu.RUnlock()
+
+	if len(handlers) == 0 && !handled {
+		c.log().Debug("Received notification", slog.String("method", notification.Method))
+		return
+	}
+
+	for _, handler := range handlers {
+		handler(notification)
+	}
+}
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// ReconnectPolicy controls how the client restores a session after the
+// connection to the server is lost.
+//
+// When a policy is configured, the client reconnects the transport, runs
+// Initialize again with the original ClientInfo and restores resource
+// subscriptions and the log level. A request that failed because the
+// connection dropped is retried once the session is back if it is safe to
+// repeat (listing, reading, prompts/get, completion and the like). tools/call
+// is only retried for tools the client has listed with the idempotentHint
+// annotation, because the server may already have run the tool.
+//
+// Reconnecting stops when Disconnect is called.
+type ReconnectPolicy struct {
+	// MaxAttempts is how many times to try before giving up. Defaults to 5.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first attempt. Defaults to 500ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// Multiplier grows the delay after each failed attempt. Defaults to 2.
+	Multiplier float64
+
+	// Jitter randomizes each delay by up to this fraction, so clients that
+	// lost the same server do not all come back at once. Zero disables
+	// jitter; only the zero ReconnectPolicy uses the default of 0.2.
+	Jitter float64
+}
+
+// DefaultReconnectPolicy returns the policy used for unset fields
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// withDefaults fills in unset fields from DefaultReconnectPolicy. Jitter is
+// only defaulted for the zero policy, so that zero can turn it off.
+func (p ReconnectPolicy) withDefaults() ReconnectPolicy {
+	defaults := DefaultReconnectPolicy()
+	if p == (ReconnectPolicy{}) {
+		return defaults
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaults.Multiplier
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	} else if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	return p
+}
+
+// Backoff returns the delay before the given attempt, counting from 1
+func (p ReconnectPolicy) Backoff(attempt int) time.Duration {
+	p = p.withDefaults()
+
+	delay := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(delay)
+}
+
+// idempotentMethods are the requests that are retried after a reconnect
+var idempotentMethods = map[string]bool{
+	"ping":                     true,
+	"tools/list":               true,
+	"resources/list":           true,
+	"resources/templates/list": true,
+	"resources/read":           true,
+	"resources/subscribe":      true,
+	"resources/unsubscribe":    true,
+	"prompts/list":             true,
+	"prompts/get":              true,
+	"completion/complete":      true,
+	"logging/setLevel":         true,
+}
+
+// reconnectingKey marks the context of requests sent while restoring a
+// session, so their failures do not start another reconnect
+type reconnectingKey struct{}
+
+// reconnectAttempt is a reconnect in progress; err is set before done closes
+type reconnectAttempt struct {
+	done chan struct{}
+	err  error
+}
+
+// OnReconnect registers a handler called each time the client has restored a
+// lost session. Handlers run on the reconnecting goroutine.
+func (c *Client) OnReconnect(handler func()) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.reconnectHandlers = append(c.reconnectHandlers, handler)
+}
+
+// canReconnect reports whether a failure seen with ctx may start a reconnect
+func (c *Client) canReconnect(ctx context.Context) bool {
+	return c.reconnectPolicy != nil && ctx.Value(reconnectingKey{}) == nil
+}
+
+// isConnectionError reports whether err means the connection is gone
+func isConnectionError(err error) bool {
+	var transportErr *TransportError
+	return errors.Is(err, ErrConnectionClosed) ||
+		errors.Is(err, ErrNotConnected) ||
+		errors.As(err, &transportErr)
+}
+
+// ensureSession returns nil if the session is initialized. With a reconnect
+// policy, a session lost to a connection failure is restored first.
+func (c *Client) ensureSession(ctx context.Context) error {
+	c.mu.RLock()
+	initialized, generation := c.initialized, c.generation
+	c.mu.RUnlock()
+
+	if initialized {
+		return nil
+	}
+	if !c.canReconnect(ctx) {
+		return ErrNotInitialized
+	}
+	return c.awaitReconnect(ctx, generation)
+}
+
+// awaitReconnect restores the session lost on connection generation and
+// waits until it is back, the attempt fails or ctx is done
+func (c *Client) awaitReconnect(ctx context.Context, generation int64) error {
+	attempt, err := c.startReconnect(generation)
+	if attempt == nil {
+		return err
+	}
+
+	select {
+	case <-attempt.done:
+		return attempt.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startReconnect starts restoring the session lost on connection generation,
+// or joins the attempt already running. It returns a nil attempt if there is
+// nothing to restore.
+func (c *Client) startReconnect(generation int64) (*reconnectAttempt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case c.closed || c.clientInfo == nil:
+		return nil, ErrNotInitialized
+	case c.reconnecting != nil:
+		return c.reconnecting, nil
+	case c.generation != generation && c.initialized:
+		// Someone else already restored the session
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempt := &reconnectAttempt{done: make(chan struct{})}
+	c.reconnecting = attempt
+	c.cancelReconnect = cancel
+	go c.reconnect(ctx, attempt, *c.clientInfo)
+
+	return attempt, nil
+}
+
+// reconnect runs a reconnect attempt to completion
+func (c *Client) reconnect(ctx context.Context, attempt *reconnectAttempt, clientInfo mcp.ClientInfo) {
+	err := c.redial(ctx, clientInfo)
+
+	c.mu.Lock()
+	attempt.err = err
+	c.reconnecting = nil
+	c.cancelReconnect()
+	c.cancelReconnect = nil
+	c.mu.Unlock()
+	close(attempt.done)
+
+	if err != nil {
+		c.log().Error("Giving up reconnecting", slog.Any("error", err))
+		return
+	}
+
+	c.handlersMu.RLock()
+	handlers := append([]func(){}, c.reconnectHandlers...)
+	c.handlersMu.RUnlock()
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
+// redial reconnects and re-initializes with backoff until it succeeds, the
+// policy runs out of attempts or ctx is cancelled by Disconnect
+func (c *Client) redial(ctx context.Context, clientInfo mcp.ClientInfo) error {
+	policy := c.reconnectPolicy.withDefaults()
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		delay := policy.Backoff(attempt)
+		c.log().Info("Reconnecting", slog.Duration("delay", delay.Round(time.Millisecond)),
+			slog.Int("attempt", attempt), slog.Int("max_attempts", policy.MaxAttempts))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("reconnect cancelled: %w", ErrNotInitialized)
+		}
+
+		if lastErr = c.reopen(ctx, clientInfo); lastErr == nil {
+			c.log().Info("Session restored", slog.Int("attempt", attempt))
+			return nil
+		}
+		c.log().Warn("Reconnect attempt failed", slog.Int("attempt", attempt), slog.Any("error", lastErr))
+	}
+
+	return fmt.Errorf("%w after %d attempts: %w", ErrReconnectFailed, policy.MaxAttempts, lastErr)
+}
+
+// reopen replaces the transport connection and initializes a new session
+func (c *Client) reopen(ctx context.Context, clientInfo mcp.ClientInfo) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return fmt.Errorf("client disconnected: %w", ErrNotInitialized)
+	}
+	if err := c.resetLocked(ErrConnectionClosed); err != nil {
+		c.log().Debug("Failed to close lost connection", slog.Any("error", err))
+	}
+	c.mu.Unlock()
+
+	// The transport may keep the context it connects with, and a stdio
+	// server is killed once that context ends. ctx ends when the attempt
+	// finishes, so it may only cancel the dial itself, which lets
+	// Disconnect stop a dial that hangs.
+	dialCtx, cancelDial := context.WithCancel(context.Background())
+	stopDial := context.AfterFunc(ctx, cancelDial)
+	err := c.transport.Connect(dialCtx)
+	stopDial()
+	if err != nil {
+		return NewTransportError(transportName(c.transport), "failed to connect transport", err)
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.transport.Close()
+		c.mu.Unlock()
+		return fmt.Errorf("client disconnected: %w", ErrNotInitialized)
+	}
+	c.startSessionLocked()
+	c.mu.Unlock()
+
+	initCtx, cancel := context.WithTimeout(context.WithValue(ctx, reconnectingKey{}, true), c.timeout)
+	defer cancel()
+	return c.Initialize(initCtx, clientInfo)
+}
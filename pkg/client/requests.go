@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// RequestHandler answers a JSON-RPC request sent by the server, such as
+// sampling/createMessage or roots/list.
+//
+// The returned result is sent back as the response. Returning an
+// *mcp.ErrorInfo sends that error to the server unchanged; any other error is
+// reported as an internal error.
+type RequestHandler func(ctx context.Context, request *mcp.Message) (interface{}, error)
+
+// OnRequest registers the handler for server-initiated requests with the given
+// method, replacing any previous handler. Requests without a handler are
+// answered with ErrorCodeMethodNotFound, except ping which is always answered.
+//
+// Each request is handled on its own goroutine. The context is cancelled when
+// the connection closes.
+func (c *Client) OnRequest(method string, handler RequestHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.requestHandlers[method] = handler
+}
+
+// handleRequest answers a single server-initiated request through the
+// middleware chain
+func (c *Client) handleRequest(ctx context.Context, request *mcp.Message) {
+	if err := c.send(c.respond(ctx, request)); err != nil {
+		c.log().Error("Failed to answer server request", slog.Any("request_id", request.ID), slog.String("method", request.Method), slog.Any("error", err))
+	}
+}
+
+// handleBatch answers the requests of a batch frame from the server
+// concurrently and sends the responses back as one batch frame
+func (c *Client) handleBatch(ctx context.Context, requests []*mcp.Message) {
+	responses := make([]*mcp.Message, len(requests))
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request *mcp.Message) {
+			defer wg.Done()
+			responses[i] = c.respond(ctx, request)
+		}(i, request)
+	}
+	wg.Wait()
+
+	if err := c.sendBatch(responses, true); err != nil {
+		c.log().Error("Failed to answer server batch", slog.Int("requests", len(requests)), slog.Any("error", err))
+	}
+}
+
+// respond runs a server request through the incoming middleware chain and
+// returns the response to send
+func (c *Client) respond(ctx context.Context, request *mcp.Message) *mcp.Message {
+	response, err := c.incoming(withDirection(ctx, Incoming), request)
+	if err != nil || response == nil {
+		response = c.buildResponse(request.ID, nil, err)
+	}
+	return response
+}
+
+// answerRequest dispatches a server-initiated request to its handler. It is
+// the innermost handler of the incoming middleware chain.
+func (c *Client) answerRequest(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+	c.handlersMu.RLock()
+	handler, ok := c.requestHandlers[request.Method]
+	c.handlersMu.RUnlock()
+
+	switch {
+	case ok:
+		result, err := handler(ctx, request)
+		return c.buildResponse(request.ID, result, err), nil
+	case request.Method == "ping":
+		return mcp.NewResponse(request.ID, struct{}{}), nil
+	default:
+		c.log().Warn("No handler for server request", slog.Any("request_id", request.ID), slog.String("method", request.Method))
+		return mcp.NewErrorResponse(request.ID, mcp.ErrorCodeMethodNotFound,
+			"method not found: "+request.Method, nil), nil
+	}
+}
+
+// buildResponse turns a handler's return values into a response message
+func (c *Client) buildResponse(id interface{}, result interface{}, err error) *mcp.Message {
+	if err != nil {
+		var errInfo *mcp.ErrorInfo
+		if errors.As(err, &errInfo) {
+			return mcp.NewErrorResponse(id, errInfo.Code, errInfo.Message, errInfo.Data)
+		}
+		return mcp.NewErrorResponse(id, mcp.ErrorCodeInternalError, err.Error(), nil)
+	}
+
+	if result == nil {
+		// A response must carry a result, even if it is empty
+		result = struct{}{}
+	}
+	return mcp.NewResponse(id, result)
+}
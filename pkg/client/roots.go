@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// SetRoots sets the filesystem roots exposed to the server through roots/list.
+//
+// Calling SetRoots before Initialize advertises the roots capability. Once the
+// session is initialized, every change notifies the server with
+// notifications/roots/list_changed. A session that started without roots
+// did not offer them to the server, so SetRoots then fails and changes
+// nothing.
+func (c *Client) SetRoots(roots []mcp.Root) error {
+	if roots == nil {
+		roots = []mcp.Root{}
+	}
+	initialized := c.IsInitialized()
+
+	c.handlersMu.Lock()
+	if initialized && !c.rootsAdvertised {
+		c.handlersMu.Unlock()
+		return fmt.Errorf("roots must be set before Initialize")
+	}
+	c.roots = append([]mcp.Root(nil), roots...)
+	c.requestHandlers["roots/list"] = c.handleListRoots
+	c.handlersMu.Unlock()
+
+	if !initialized {
+		return nil
+	}
+
+	c.log().Debug("Roots changed, notifying server", slog.Int("count", len(roots)))
+	if err := c.send(mcp.NewNotification(mcp.NotificationRootsListChanged, nil)); err != nil {
+		return fmt.Errorf("failed to send roots list changed notification: %w", err)
+	}
+	return nil
+}
+
+// GetRoots returns the roots currently exposed to the server
+func (c *Client) GetRoots() []mcp.Root {
+	c.handlersMu.RLock()
+	defer c.handlersMu.RUnlock()
+
+	roots := make([]mcp.Root, len(c.roots))
+	copy(roots, c.roots)
+	return roots
+}
+
+// handleListRoots answers roots/list requests
+func (c *Client) handleListRoots(ctx context.Context, request *mcp.Message) (interface{}, error) {
+	return mcp.ListRootsResult{Roots: c.GetRoots()}, nil
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// SamplingHandler generates LLM completions on behalf of the server.
+//
+// When a handler is set the client advertises the sampling capability during
+// Initialize and answers sampling/createMessage requests with it.
+type SamplingHandler interface {
+	CreateMessage(ctx context.Context, request *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error)
+}
+
+// SamplingHandlerFunc adapts a function to the SamplingHandler interface
+type SamplingHandlerFunc func(ctx context.Context, request *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error)
+
+// CreateMessage calls f(ctx, request)
+func (f SamplingHandlerFunc) CreateMessage(ctx context.Context, request *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	return f(ctx, request)
+}
+
+// SetSamplingHandler sets the handler used to answer sampling requests.
+//
+// The sampling capability is negotiated during Initialize, so the handler must
+// be set before Initialize is called. Passing nil disables sampling.
+func (c *Client) SetSamplingHandler(handler SamplingHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+
+	c.samplingHandler = handler
+	if handler == nil {
+		delete(c.requestHandlers, "sampling/createMessage")
+		return
+	}
+	c.requestHandlers["sampling/createMessage"] = c.handleCreateMessage
+}
+
+// handleCreateMessage answers sampling/createMessage requests
+func (c *Client) handleCreateMessage(ctx context.Context, request *mcp.Message) (interface{}, error) {
+	c.handlersMu.RLock()
+	handler := c.samplingHandler
+	c.handlersMu.RUnlock()
+
+	if handler == nil {
+		return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeMethodNotFound, Message: "sampling not supported"}
+	}
+
+	var params mcp.CreateMessageRequest
+	if err := parseResult(request.Params, &params); err != nil {
+		return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidParams, Message: err.Error()}
+	}
+
+	c.log().Debug("Handling sampling request", slog.Int("count", len(params.Messages)))
+	return handler.CreateMessage(ctx, &params)
+}
+
+// OpenAISamplingHandler answers sampling requests using an OpenAI-compatible
+// chat completions endpoint, such as a local llama.cpp, Ollama or vLLM server.
+type OpenAISamplingHandler struct {
+	BaseURL    string // e.g. "http://localhost:11434/v1"
+	APIKey     string // optional bearer token
+	Model      string // model used when the server expresses no preference
+	HTTPClient *http.Client
+}
+
+// NewOpenAISamplingHandler creates a sampling handler for the given endpoint
+func NewOpenAISamplingHandler(baseURL, apiKey, model string) *OpenAISamplingHandler {
+	return &OpenAISamplingHandler{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		APIKey:     apiKey,
+		Model:      model,
+		HTTPClient: &http.Client{Timeout: 120 * time.Second},
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+	Temperature *float64        `json:"temperature,omitempty"`
+	Stop        []string        `json:"stop,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// CreateMessage sends the conversation to the chat completions endpoint
+func (h *OpenAISamplingHandler) CreateMessage(ctx context.Context, request *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	chatRequest := openAIChatRequest{
+		Model:       h.Model,
+		MaxTokens:   request.MaxTokens,
+		Temperature: request.Temperature,
+		Stop:        request.StopSequences,
+	}
+
+	// Fall back to the server's first model hint if no model is configured
+	if chatRequest.Model == "" && request.ModelPreferences != nil && len(request.ModelPreferences.Hints) > 0 {
+		chatRequest.Model = request.ModelPreferences.Hints[0].Name
+	}
+
+	if request.SystemPrompt != "" {
+		chatRequest.Messages = append(chatRequest.Messages, openAIMessage{Role: "system", Content: request.SystemPrompt})
+	}
+	for _, message := range request.Messages {
+		if message.Content.Type != "text" {
+			return nil, fmt.Errorf("unsupported sampling content type: %s", message.Content.Type)
+		}
+		chatRequest.Messages = append(chatRequest.Messages, openAIMessage{Role: message.Role, Content: message.Content.Text})
+	}
+
+	body, err := json.Marshal(chatRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chat request: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, h.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if h.APIKey != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+h.APIKey)
+	}
+
+	httpClient := h.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("chat request failed: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chat request failed: %s", httpResponse.Status)
+	}
+
+	var chatResponse openAIChatResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&chatResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse chat response: %w", err)
+	}
+	if len(chatResponse.Choices) == 0 {
+		return nil, fmt.Errorf("chat response has no choices")
+	}
+
+	choice := chatResponse.Choices[0]
+	return &mcp.CreateMessageResult{
+		Role:       "assistant",
+		Content:    mcp.Content{Type: "text", Text: choice.Message.Content},
+		Model:      chatResponse.Model,
+		StopReason: openAIStopReason(choice.FinishReason),
+	}, nil
+}
+
+// openAIStopReason maps an OpenAI finish_reason to an MCP stop reason
+func openAIStopReason(reason string) string {
+	switch reason {
+	case "stop":
+		return "endTurn"
+	case "length":
+		return "maxTokens"
+	default:
+		return reason
+	}
+}
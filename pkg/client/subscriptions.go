@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// SubscribeResource asks the server to send notifications/resources/updated
+// whenever the resource at uri changes, and calls onUpdate for each of them.
+//
+// Subscriptions survive reconnects: they are re-established every time
+// Initialize completes. Subscribing to the same uri again replaces onUpdate.
+func (c *Client) SubscribeResource(ctx context.Context, uri string, onUpdate func(mcp.ResourceUpdatedNotification)) error {
+	if err := c.ensureSession(ctx); err != nil {
+		return err
+	}
+
+	if caps := c.GetServerCapabilities(); caps == nil || caps.Resources == nil || !caps.Resources.Subscribe {
+		return fmt.Errorf("resource subscriptions: %w", ErrNotSupported)
+	}
+
+	c.log().Debug("Subscribing to resource", slog.String("uri", uri))
+
+	// Register the callback first, so an update sent right after the server
+	// acknowledges the subscription is not lost
+	c.handlersMu.Lock()
+	previous, subscribed := c.subscriptions[uri]
+	c.subscriptions[uri] = onUpdate
+	c.handlersMu.Unlock()
+
+	if err := c.subscribe(ctx, uri); err != nil {
+		c.handlersMu.Lock()
+		if subscribed {
+			c.subscriptions[uri] = previous
+		} else {
+			delete(c.subscriptions, uri)
+		}
+		c.handlersMu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// UnsubscribeResource stops update notifications for the resource at uri
+func (c *Client) UnsubscribeResource(ctx context.Context, uri string) error {
+	c.handlersMu.Lock()
+	delete(c.subscriptions, uri)
+	c.handlersMu.Unlock()
+
+	if err := c.ensureSession(ctx); err != nil {
+		return err
+	}
+
+	c.log().Debug("Unsubscribing from resource", slog.String("uri", uri))
+
+	response, err := c.sendRequest(ctx, "resources/unsubscribe", mcp.UnsubscribeRequest{URI: uri})
+	if err != nil {
+		return fmt.Errorf("unsubscribe request failed: %w", err)
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("unsubscribe error: %w", newMCPError(response.Error))
+	}
+
+	return nil
+}
+
+// Subscriptions returns the URIs of all subscribed resources
+func (c *Client) Subscriptions() []string {
+	c.handlersMu.RLock()
+	defer c.handlersMu.RUnlock()
+
+	uris := make([]string, 0, len(c.subscriptions))
+	for uri := range c.subscriptions {
+		uris = append(uris, uri)
+	}
+	return uris
+}
+
+// subscribe sends a resources/subscribe request
+func (c *Client) subscribe(ctx context.Context, uri string) error {
+	response, err := c.sendRequest(ctx, "resources/subscribe", mcp.SubscribeRequest{URI: uri})
+	if err != nil {
+		return fmt.Errorf("subscribe request failed: %w", err)
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("subscribe error: %w", newMCPError(response.Error))
+	}
+
+	return nil
+}
+
+// restoreSubscriptions re-subscribes to every tracked resource after a new
+// session has been initialized
+func (c *Client) restoreSubscriptions(ctx context.Context) {
+	uris := c.Subscriptions()
+	if len(uris) == 0 {
+		return
+	}
+
+	if caps := c.GetServerCapabilities(); caps == nil || caps.Resources == nil || !caps.Resources.Subscribe {
+		c.log().Warn("Server no longer supports subscriptions, dropping them", slog.Int("count", len(uris)))
+		return
+	}
+
+	for _, uri := range uris {
+		if err := c.subscribe(ctx, uri); err != nil {
+			c.log().Warn("Failed to restore subscription", slog.String("uri", uri), slog.Any("error", err))
+		}
+	}
+}
+
+// dispatchResourceUpdated routes a resource update to its subscription
+func (c *Client) dispatchResourceUpdated(notification *mcp.Message) bool {
+	var params mcp.ResourceUpdatedNotification
+	if err := parseResult(notification.Params, &params); err != nil {
+		return false
+	}
+
+	c.handlersMu.RLock()
+	onUpdate, ok := c.subscriptions[params.URI]
+	c.handlersMu.RUnlock()
+
+	if ok && onUpdate != nil {
+		onUpdate(params)
+	}
+	return ok
+}
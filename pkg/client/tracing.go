@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by the client
+const tracerName = "github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+
+// Span attributes set by the client, next to the rpc.* ones of the
+// OpenTelemetry JSON-RPC conventions
+const (
+	attributeServerName   = attribute.Key("mcp.server.name")
+	attributeToolName     = attribute.Key("mcp.tool.name")
+	attributeErrorCode    = attribute.Key("rpc.jsonrpc.error_code")
+	attributeErrorMessage = attribute.Key("rpc.jsonrpc.error_message")
+)
+
+// tracing creates a span per JSON-RPC request and propagates the trace
+// context through params._meta
+type tracing struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	// spans holds the spans of outgoing requests waiting for a response, by
+	// request ID, so notifications can be recorded on them
+	mu    sync.Mutex
+	spans map[string]*requestSpan
+}
+
+// requestSpan is the span of an outgoing request
+type requestSpan struct {
+	span          trace.Span
+	progressToken string
+}
+
+func newTracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) *tracing {
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+	return &tracing{
+		tracer:     provider.Tracer(tracerName),
+		propagator: propagator,
+		spans:      make(map[string]*requestSpan),
+	}
+}
+
+// traceMiddleware wraps outgoing requests in client spans and incoming ones in
+// server spans
+func (c *Client) traceMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			if RequestDirection(ctx) == Incoming {
+				return c.traceIncoming(ctx, request, next)
+			}
+			return c.traceOutgoing(ctx, request, next)
+		}
+	}
+}
+
+// traceOutgoing starts a client span and injects its context into the request
+func (c *Client) traceOutgoing(ctx context.Context, request *mcp.Message, next Handler) (*mcp.Message, error) {
+	t := c.tracing
+	ctx, span := t.tracer.Start(ctx, request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(c.spanAttributes(request)...))
+	defer span.End()
+
+	carrier := propagation.MapCarrier{}
+	t.propagator.Inject(ctx, carrier)
+	for _, key := range carrier.Keys() {
+		if err := request.SetMeta(key, carrier.Get(key)); err != nil {
+			span.RecordError(err)
+			break
+		}
+	}
+
+	id := fmt.Sprint(request.ID)
+	tracked := &requestSpan{span: span}
+	if token, ok := requestMeta(request.Params)["progressToken"]; ok {
+		tracked.progressToken = fmt.Sprint(token)
+	}
+	t.mu.Lock()
+	t.spans[id] = tracked
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.spans, id)
+		t.mu.Unlock()
+	}()
+
+	response, err := next(ctx, request)
+	endSpan(span, response, err)
+	return response, err
+}
+
+// traceIncoming starts a server span, continuing the trace the server sent in
+// params._meta if any
+func (c *Client) traceIncoming(ctx context.Context, request *mcp.Message, next Handler) (*mcp.Message, error) {
+	t := c.tracing
+
+	carrier := propagation.MapCarrier{}
+	for key, value := range requestMeta(request.Params) {
+		if text, ok := value.(string); ok {
+			carrier.Set(key, text)
+		}
+	}
+	ctx = t.propagator.Extract(ctx, carrier)
+
+	ctx, span := t.tracer.Start(ctx, request.Method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(c.spanAttributes(request)...))
+	defer span.End()
+
+	response, err := next(ctx, request)
+	endSpan(span, response, err)
+	return response, err
+}
+
+// spanAttributes describes a request
+func (c *Client) spanAttributes(request *mcp.Message) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", request.Method),
+		attribute.String("rpc.jsonrpc.version", "2.0"),
+		attribute.String("rpc.jsonrpc.request_id", fmt.Sprint(request.ID)),
+	}
+	if info := c.GetServerInfo(); info != nil {
+		attributes = append(attributes, attributeServerName.String(info.Name))
+	}
+	if request.Method == "tools/call" {
+		if name := toolNameOf(request.Params); name != "" {
+			attributes = append(attributes, attributeToolName.String(name))
+		}
+	}
+	return attributes
+}
+
+// endSpan records how a request ended
+func endSpan(span trace.Span, response *mcp.Message, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case response != nil && response.Error != nil:
+		span.SetAttributes(
+			attributeErrorCode.Int(response.Error.Code),
+			attributeErrorMessage.String(response.Error.Message))
+		span.SetStatus(codes.Error, response.Error.Message)
+	}
+}
+
+// traceNotification records a notification from the server as an event on
+// the spans of the requests in flight. Progress notifications are only
+// recorded on the request they report on.
+func (c *Client) traceNotification(notification *mcp.Message) {
+	if c.tracing == nil {
+		return
+	}
+
+	var options []trace.EventOption
+	token := ""
+	if notification.Method == mcp.NotificationProgress {
+		var progress mcp.ProgressNotification
+		if err := parseResult(notification.Params, &progress); err == nil {
+			token = fmt.Sprint(progress.ProgressToken)
+			attributes := []attribute.KeyValue{attribute.Float64("mcp.progress", progress.Progress)}
+			if progress.Total > 0 {
+				attributes = append(attributes, attribute.Float64("mcp.progress.total", progress.Total))
+			}
+			if progress.Message != "" {
+				attributes = append(attributes, attribute.String("mcp.progress.message", progress.Message))
+			}
+			options = append(options, trace.WithAttributes(attributes...))
+		}
+	}
+
+	c.tracing.mu.Lock()
+	defer c.tracing.mu.Unlock()
+	for _, tracked := range c.tracing.spans {
+		if token == "" || tracked.progressToken == token {
+			tracked.span.AddEvent(notification.Method, options...)
+		}
+	}
+}
+
+// traceCancellation records that the client cancelled a request
+func (c *Client) traceCancellation(requestID int64, reason string) {
+	if c.tracing == nil {
+		return
+	}
+
+	c.tracing.mu.Lock()
+	defer c.tracing.mu.Unlock()
+	if tracked, ok := c.tracing.spans[fmt.Sprint(requestID)]; ok {
+		tracked.span.AddEvent(mcp.NotificationCancelled,
+			trace.WithAttributes(attribute.String("mcp.cancel.reason", reason)))
+	}
+}
+
+// requestMeta returns the params._meta object of a request, or nil
+func requestMeta(params interface{}) map[string]interface{} {
+	object, ok := params.(map[string]interface{})
+	if !ok {
+		if params == nil {
+			return nil
+		}
+		data, err := json.Marshal(params)
+		if err != nil || json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+	}
+	meta, _ := object["_meta"].(map[string]interface{})
+	return meta
+}
+
+// toolNameOf returns the tool name of tools/call params
+func toolNameOf(params interface{}) string {
+	switch p := params.(type) {
+	case mcp.CallToolRequest:
+		return p.Name
+	case map[string]interface{}:
+		name, _ := p["name"].(string)
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Call executes a tool with typed arguments and decodes its result into T.
+//
+// args is encoded to JSON and sent as the tool's arguments, so struct fields
+// are named by their json tags; it must encode to a JSON object, and nil
+// sends no arguments. The result is decoded as by CallToolInto: from
+// structuredContent, or from a single JSON text block for servers on older
+// protocol versions. If the tool reports an error the returned error wraps
+// ErrToolError.
+//
+// Example:
+//
+//	type weatherArgs struct {
+//		City string `json:"city"`
+//		Days int    `json:"days,omitempty"`
+//	}
+//	type forecast struct {
+//		Temperature float64 `json:"temperature"`
+//		Conditions  string  `json:"conditions"`
+//	}
+//	result, err := client.Call[forecast](ctx, c, "get_weather", weatherArgs{City: "Paris"})
+func Call[T any](ctx context.Context, c *Client, name string, args interface{}) (T, error) {
+	var zero T
+
+	arguments, err := toolArguments(args)
+	if err != nil {
+		return zero, fmt.Errorf("failed to encode arguments of tool %s: %w", name, err)
+	}
+
+	var out T
+	if _, err := c.CallToolInto(ctx, name, arguments, &out); err != nil {
+		return zero, err
+	}
+	return out, nil
+}
+
+// ReadResourceAs reads a JSON resource and decodes it into T.
+//
+// The first item of the resource's contents is decoded, whether it is text or
+// a base64 blob. If it declares a MIME type, it must be application/json or a
+// +json type; otherwise the returned error wraps ErrNotJSON.
+//
+// Example:
+//
+//	type settings struct {
+//		Theme string `json:"theme"`
+//	}
+//	s, err := client.ReadResourceAs[settings](ctx, c, "config://settings")
+func ReadResourceAs[T any](ctx context.Context, c *Client, uri string) (T, error) {
+	var zero T
+
+	result, err := c.ReadResource(ctx, uri)
+	if err != nil {
+		return zero, err
+	}
+	if len(result.Contents) == 0 {
+		return zero, fmt.Errorf("resource %s has no contents", uri)
+	}
+
+	contents := result.Contents[0]
+	if contents.MimeType != "" && !isJSONMimeType(contents.MimeType) {
+		return zero, fmt.Errorf("resource %s has MIME type %s: %w", uri, contents.MimeType, ErrNotJSON)
+	}
+
+	data := []byte(contents.Text)
+	if contents.IsBlob() {
+		if data, err = contents.DecodeBlob(); err != nil {
+			return zero, fmt.Errorf("failed to decode blob of resource %s: %w", uri, err)
+		}
+	}
+
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return zero, fmt.Errorf("failed to decode resource %s: %w", uri, err)
+	}
+	return out, nil
+}
+
+// toolArguments converts typed tool arguments into the arguments object of a
+// tools/call request
+func toolArguments(args interface{}) (map[string]interface{}, error) {
+	switch a := args.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return a, nil
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if len(data) == 0 || data[0] != '{' {
+		return nil, fmt.Errorf("arguments must encode to a JSON object, got %s", data)
+	}
+
+	var arguments map[string]interface{}
+	if err := json.Unmarshal(data, &arguments); err != nil {
+		return nil, err
+	}
+	return arguments, nil
+}
+
+// isJSONMimeType reports whether a MIME type describes JSON
+func isJSONMimeType(mimeType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
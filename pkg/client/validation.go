@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/jsonschema"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// ValidateToolArguments checks arguments against the input schema of the
+// named tool without calling it.
+//
+// The schema comes from the last tool list; the tools are listed first if
+// they have not been listed yet or the server reported a change since. It
+// returns a *ValidationError describing every violation, or an error wrapping
+// ErrUnknownTool if the server has no such tool.
+//
+// Example:
+//
+//	err := client.ValidateToolArguments(ctx, "search", args)
+//	var invalid *client.ValidationError
+//	if errors.As(err, &invalid) {
+//		for _, v := range invalid.Violations {
+//			fmt.Println(v) // query: is required
+//		}
+//	}
+func (c *Client) ValidateToolArguments(ctx context.Context, name string, arguments map[string]interface{}) error {
+	tool, err := c.toolDefinition(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Validate missing arguments as an empty object so required fields are reported
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	if violations := jsonschema.Validate(tool.InputSchema, arguments); len(violations) > 0 {
+		return &ValidationError{Tool: name, Violations: violations}
+	}
+	return nil
+}
+
+// toolDefinition returns the current definition of the named tool, listing
+// the tools if the catalog does not hold an up-to-date list.
+//
+// A tool missing from a list fetched earlier may have been added since by a
+// server that does not send list_changed, so the tools are listed again once
+// before it is reported as unknown.
+func (c *Client) toolDefinition(ctx context.Context, name string) (mcp.Tool, error) {
+	c.catalogMu.Lock()
+	current := c.catalog.tools.fetched && !c.catalog.tools.stale
+	c.catalogMu.Unlock()
+
+	if !current {
+		if _, err := c.ListTools(ctx); err != nil {
+			return mcp.Tool{}, fmt.Errorf("failed to list tools for validation: %w", err)
+		}
+	}
+
+	tool, ok := c.cachedTool(name)
+	if !ok && current {
+		c.catalogMu.Lock()
+		c.catalog.tools.invalidate()
+		c.catalogMu.Unlock()
+
+		if _, err := c.ListTools(ctx); err != nil {
+			return mcp.Tool{}, fmt.Errorf("failed to list tools for validation: %w", err)
+		}
+		tool, ok = c.cachedTool(name)
+	}
+	if !ok {
+		return mcp.Tool{}, fmt.Errorf("tool %s: %w", name, ErrUnknownTool)
+	}
+	return tool, nil
+}
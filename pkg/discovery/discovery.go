@@ -25,9 +25,10 @@ package discovery
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,22 +50,22 @@ type ServerInfo struct {
 
 // Discovery handles MCP server discovery
 type Discovery struct {
-	logger  *log.Logger
+	logger  *slog.Logger
 	timeout time.Duration
 }
 
 // NewDiscovery creates a new server discovery instance.
 //
-// If logger is nil, log.Default() will be used.
+// If logger is nil, slog.Default() will be used.
 // The discovery instance uses a default timeout of 5 seconds for connection tests.
 //
 // Example:
 //
-//	disco := NewDiscovery(log.Default())
+//	disco := NewDiscovery(slog.Default())
 //	disco.SetTimeout(10 * time.Second) // Optional: custom timeout
-func NewDiscovery(logger *log.Logger) *Discovery {
+func NewDiscovery(logger *slog.Logger) *Discovery {
 	if logger == nil {
-		logger = log.Default()
+		logger = slog.Default()
 	}
 	return &Discovery{
 		logger:  logger,
@@ -74,7 +75,7 @@ func NewDiscovery(logger *log.Logger) *Discovery {
 
 // DiscoverTCPServers scans for MCP servers on TCP ports
 func (d *Discovery) DiscoverTCPServers(ctx context.Context, host string, ports []int) []ServerInfo {
-	d.logger.Printf("Scanning for MCP servers on %s, ports: %v", host, ports)
+	d.logger.Debug("Scanning for MCP servers", slog.String("host", host), slog.Any("ports", ports))
 
 	var servers []ServerInfo
 
@@ -89,23 +90,23 @@ func (d *Discovery) DiscoverTCPServers(ctx context.Context, host string, ports [
 				Description: fmt.Sprintf("MCP server on TCP %s:%d", host, port),
 			}
 			servers = append(servers, server)
-			d.logger.Printf("Found TCP server: %s:%d", host, port)
+			d.logger.Info("Found TCP server", slog.String("host", host), slog.Int("port", port))
 		}
 	}
 
-	d.logger.Printf("TCP discovery complete. Found %d servers", len(servers))
+	d.logger.Debug("TCP discovery complete", slog.Int("count", len(servers)))
 	return servers
 }
 
 // DiscoverDockerServers scans for MCP servers in Docker containers
 func (d *Discovery) DiscoverDockerServers(ctx context.Context) []ServerInfo {
-	d.logger.Println("Scanning for MCP servers in Docker containers...")
+	d.logger.Debug("Scanning for MCP servers in Docker containers")
 
 	var servers []ServerInfo
 
 	// Check if Docker is available
 	if !d.isDockerAvailable() {
-		d.logger.Println("Docker not available, skipping Docker discovery")
+		d.logger.Info("Docker not available, skipping Docker discovery")
 		return servers
 	}
 
@@ -123,17 +124,17 @@ func (d *Discovery) DiscoverDockerServers(ctx context.Context) []ServerInfo {
 				Description: fmt.Sprintf("MCP server in Docker container %s", container.Name),
 			}
 			servers = append(servers, server)
-			d.logger.Printf("Found Docker MCP server: %s", container.Name)
+			d.logger.Info("Found Docker MCP server", slog.String("container", container.Name))
 		}
 	}
 
-	d.logger.Printf("Docker discovery complete. Found %d servers", len(servers))
+	d.logger.Debug("Docker discovery complete", slog.Int("count", len(servers)))
 	return servers
 }
 
 // CreateDockerMCPTransport creates a transport for the Docker MCP configuration
 func (d *Discovery) CreateDockerMCPTransport() transport.Transport {
-	d.logger.Println("Creating Docker MCP transport with direct TCP connection")
+	d.logger.Debug("Creating Docker MCP transport with direct TCP connection")
 
 	// Instead of using alpine/socat proxy (which fails during tool calls),
 	// create a direct TCP connection to localhost:8811
@@ -159,7 +160,7 @@ func (d *Discovery) DiscoverCommonPorts(ctx context.Context, host string) []Serv
 
 // DiscoverAll performs comprehensive server discovery
 func (d *Discovery) DiscoverAll(ctx context.Context, host string) []ServerInfo {
-	d.logger.Println("Starting comprehensive MCP server discovery...")
+	d.logger.Debug("Starting MCP server discovery")
 
 	var allServers []ServerInfo
 
@@ -181,13 +182,13 @@ func (d *Discovery) DiscoverAll(ctx context.Context, host string) []ServerInfo {
 	}
 	allServers = append(allServers, dockerMCP)
 
-	d.logger.Printf("Discovery complete. Found %d total servers", len(allServers))
+	d.logger.Info("Discovery complete", slog.Int("count", len(allServers)))
 	return allServers
 }
 
 // isPortOpen checks if a TCP port is open
 func (d *Discovery) isPortOpen(host string, port int) bool {
-	address := fmt.Sprintf("%s:%d", host, port)
+	address := net.JoinHostPort(host, strconv.Itoa(port))
 	conn, err := net.DialTimeout("tcp", address, d.timeout)
 	if err != nil {
 		return false
@@ -216,7 +217,7 @@ func (d *Discovery) getDockerContainers() []DockerContainer {
 	cmd := exec.Command("docker", "ps", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.Ports}}")
 	output, err := cmd.Output()
 	if err != nil {
-		d.logger.Printf("Failed to list Docker containers: %v", err)
+		d.logger.Warn("Failed to list Docker containers", slog.Any("error", err))
 		return nil
 	}
 
@@ -285,7 +286,7 @@ func (d *Discovery) SetTimeout(timeout time.Duration) {
 
 // ScanPortRange scans a range of ports for MCP servers
 func (d *Discovery) ScanPortRange(ctx context.Context, host string, startPort, endPort int) []ServerInfo {
-	d.logger.Printf("Scanning port range %d-%d on %s", startPort, endPort, host)
+	d.logger.Debug("Scanning port range", slog.String("host", host), slog.Int("start_port", startPort), slog.Int("end_port", endPort))
 
 	var ports []int
 	for port := startPort; port <= endPort; port++ {
@@ -297,19 +298,19 @@ func (d *Discovery) ScanPortRange(ctx context.Context, host string, startPort, e
 
 // TestConnection tests if a discovered server is actually an MCP server
 func (d *Discovery) TestConnection(ctx context.Context, server ServerInfo) bool {
-	d.logger.Printf("Testing connection to %s", server.Name)
+	d.logger.Debug("Testing connection", slog.String("server", server.Name))
 
 	testCtx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
 
 	err := server.Transport.Connect(testCtx)
 	if err != nil {
-		d.logger.Printf("Failed to connect to %s: %v", server.Name, err)
+		d.logger.Warn("Failed to connect", slog.String("server", server.Name), slog.Any("error", err))
 		return false
 	}
 
 	defer server.Transport.Close()
 
-	d.logger.Printf("Successfully connected to %s", server.Name)
+	d.logger.Info("Connection test succeeded", slog.String("server", server.Name))
 	return true
 }
@@ -0,0 +1,537 @@
+// Package jsonschema validates JSON values against the parts of JSON Schema
+// that MCP servers use to describe tool arguments.
+//
+// Supported keywords are type, enum, const, properties, required,
+// additionalProperties, minProperties, maxProperties, items, prefixItems,
+// minItems, maxItems, uniqueItems, minLength, maxLength, pattern, format,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, allOf,
+// anyOf, oneOf, not and local $ref pointers ("#/$defs/address"). Other
+// keywords are ignored, so unknown extensions never reject a value.
+//
+//	violations := jsonschema.Validate(tool.InputSchema, arguments)
+//	for _, v := range violations {
+//		fmt.Println(v) // location.lat: expected number, got string
+//	}
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Violation is a single way in which a value does not match its schema
+type Violation struct {
+	// Path locates the offending value, e.g. "items[2].name". It is empty
+	// for the value itself.
+	Path    string
+	Message string
+}
+
+// String formats the violation as "path: message"
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// Validate checks value against schema and returns every violation found, or
+// nil if value is valid. A nil schema accepts anything.
+//
+// value and schema may hold any Go values that encode to JSON; they are
+// compared the way a server would see them on the wire.
+func Validate(schema map[string]interface{}, value interface{}) []Violation {
+	if schema == nil {
+		return nil
+	}
+
+	normalized, err := normalize(value)
+	if err != nil {
+		return []Violation{{Message: fmt.Sprintf("cannot be encoded as JSON: %v", err)}}
+	}
+
+	// Schemas built in Go may hold []string and the like
+	decoded, err := normalize(schema)
+	if err != nil {
+		return []Violation{{Message: fmt.Sprintf("schema error: %v", err)}}
+	}
+	root, _ := decoded.(map[string]interface{})
+
+	v := &validator{root: root}
+	v.validate(root, normalized, "")
+	return v.violations
+}
+
+// normalize converts value to the types encoding/json decodes into
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// validator collects violations while walking a schema
+type validator struct {
+	root       map[string]interface{}
+	violations []Violation
+	depth      int
+}
+
+// maxRefDepth stops $ref cycles that never consume any input
+const maxRefDepth = 64
+
+func (v *validator) addf(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value is valid against schema without recording
+// violations
+func (v *validator) matches(schema interface{}, value interface{}, path string) bool {
+	sub := &validator{root: v.root, depth: v.depth}
+	sub.validate(schema, value, path)
+	return len(sub.violations) == 0
+}
+
+// validate checks value against a schema, which may be an object or a boolean
+func (v *validator) validate(schema interface{}, value interface{}, path string) {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			v.addf(path, "is not allowed")
+		}
+		return
+	case map[string]interface{}:
+		v.validateObjectSchema(s, value, path)
+	}
+}
+
+func (v *validator) validateObjectSchema(schema map[string]interface{}, value interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			v.addf(path, "schema error: %v", err)
+			return
+		}
+		if v.depth >= maxRefDepth {
+			v.addf(path, "schema error: $ref nesting too deep")
+			return
+		}
+		v.depth++
+		v.validate(target, value, path)
+		v.depth--
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		v.addf(path, "expected %s, got %s", describeTypes(types), typeOf(value))
+		// Further checks would only repeat the type mismatch
+		return
+	}
+
+	if constant, ok := schema["const"]; ok && !equal(constant, value) {
+		v.addf(path, "must be %s", encode(constant))
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if equal(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			options := make([]string, len(enum))
+			for i, allowed := range enum {
+				options[i] = encode(allowed)
+			}
+			v.addf(path, "must be one of %s", strings.Join(options, ", "))
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(schema, value, path)
+	case []interface{}:
+		v.validateArray(schema, value, path)
+	case string:
+		v.validateString(schema, value, path)
+	case float64:
+		v.validateNumber(schema, value, path)
+	}
+
+	v.validateCombinators(schema, value, path)
+}
+
+func (v *validator) validateObject(schema map[string]interface{}, object map[string]interface{}, path string) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := object[name]; !present {
+					v.addf(joinPath(path, name), "is required")
+				}
+			}
+		}
+	}
+
+	if min, ok := intKeyword(schema, "minProperties"); ok && len(object) < min {
+		v.addf(path, "must have at least %d properties", min)
+	}
+	if max, ok := intKeyword(schema, "maxProperties"); ok && len(object) > max {
+		v.addf(path, "must have at most %d properties", max)
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+
+	// Walk properties in a stable order so violations are reproducible
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := joinPath(path, name)
+		if propertySchema, ok := properties[name]; ok {
+			v.validate(propertySchema, object[name], propertyPath)
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			v.addf(propertyPath, "is not a known property")
+			continue
+		}
+		v.validate(additional, object[name], propertyPath)
+	}
+}
+
+func (v *validator) validateArray(schema map[string]interface{}, array []interface{}, path string) {
+	if min, ok := intKeyword(schema, "minItems"); ok && len(array) < min {
+		v.addf(path, "must have at least %d items", min)
+	}
+	if max, ok := intKeyword(schema, "maxItems"); ok && len(array) > max {
+		v.addf(path, "must have at most %d items", max)
+	}
+
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+	outer:
+		for i := range array {
+			for j := 0; j < i; j++ {
+				if equal(array[i], array[j]) {
+					v.addf(path, "items %d and %d are equal, items must be unique", j, i)
+					break outer
+				}
+			}
+		}
+	}
+
+	// prefixItems (and the older array form of items) describe positions;
+	// items then applies to the rest
+	prefix, _ := schema["prefixItems"].([]interface{})
+	items := schema["items"]
+	if tuple, ok := items.([]interface{}); ok {
+		prefix, items = tuple, schema["additionalItems"]
+	}
+
+	for i, item := range array {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(prefix) {
+			v.validate(prefix[i], item, itemPath)
+		} else if items != nil {
+			v.validate(items, item, itemPath)
+		}
+	}
+}
+
+func (v *validator) validateString(schema map[string]interface{}, s string, path string) {
+	length := utf8.RuneCountInString(s)
+	if min, ok := intKeyword(schema, "minLength"); ok && length < min {
+		v.addf(path, "must be at least %d characters long", min)
+	}
+	if max, ok := intKeyword(schema, "maxLength"); ok && length > max {
+		v.addf(path, "must be at most %d characters long", max)
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.addf(path, "schema error: invalid pattern %q", pattern)
+		} else if !re.MatchString(s) {
+			v.addf(path, "must match pattern %q", pattern)
+		}
+	}
+
+	if format, ok := schema["format"].(string); ok {
+		if check, known := formats[format]; known && !check(s) {
+			v.addf(path, "must be a valid %s", format)
+		}
+	}
+}
+
+func (v *validator) validateNumber(schema map[string]interface{}, n float64, path string) {
+	if min, ok := schema["minimum"].(float64); ok {
+		// Draft 4 spelled exclusive bounds as a boolean next to minimum
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive && n <= min {
+			v.addf(path, "must be greater than %s", formatNumber(min))
+		} else if n < min {
+			v.addf(path, "must be at least %s", formatNumber(min))
+		}
+	}
+	if max, ok := schema["maximum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive && n >= max {
+			v.addf(path, "must be less than %s", formatNumber(max))
+		} else if n > max {
+			v.addf(path, "must be at most %s", formatNumber(max))
+		}
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && n <= min {
+		v.addf(path, "must be greater than %s", formatNumber(min))
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && n >= max {
+		v.addf(path, "must be less than %s", formatNumber(max))
+	}
+	if divisor, ok := schema["multipleOf"].(float64); ok && divisor > 0 {
+		quotient := n / divisor
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			v.addf(path, "must be a multiple of %s", formatNumber(divisor))
+		}
+	}
+}
+
+func (v *validator) validateCombinators(schema map[string]interface{}, value interface{}, path string) {
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path)
+		}
+	}
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if v.matches(sub, value, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addf(path, "does not match any of the allowed schemas")
+		}
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		count := 0
+		for _, sub := range oneOf {
+			if v.matches(sub, value, path) {
+				count++
+			}
+		}
+		switch {
+		case count == 0:
+			v.addf(path, "does not match any of the allowed schemas")
+		case count > 1:
+			v.addf(path, "matches %d schemas, expected exactly one", count)
+		}
+	}
+
+	if not, ok := schema["not"]; ok && v.matches(not, value, path) {
+		v.addf(path, "matches a schema it must not match")
+	}
+}
+
+// resolve follows a local JSON pointer such as "#/$defs/address"
+func (v *validator) resolve(ref string) (interface{}, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+
+	var current interface{} = v.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("$ref %q not found", ref)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("$ref %q not found", ref)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("$ref %q not found", ref)
+		}
+	}
+	return current, nil
+}
+
+// matchesType checks the type keyword, a single name or a list of names
+func matchesType(types interface{}, value interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return isType(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if name, ok := name.(string); ok && isType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func isType(name string, value interface{}) bool {
+	actual := typeOf(value)
+	switch name {
+	case "number":
+		return actual == "number" || actual == "integer"
+	default:
+		return actual == name
+	}
+}
+
+// typeOf returns the JSON Schema type name of a decoded JSON value
+func typeOf(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) && !math.IsInf(value, 0) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// describeTypes renders the type keyword for messages, e.g. "string or null"
+func describeTypes(types interface{}) string {
+	switch t := types.(type) {
+	case string:
+		return t
+	case []interface{}:
+		names := make([]string, 0, len(t))
+		for _, name := range t {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+// intKeyword reads a non-negative integer keyword such as minItems
+func intKeyword(schema map[string]interface{}, name string) (int, bool) {
+	n, ok := schema[name].(float64)
+	if !ok || n < 0 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// equal compares two decoded JSON values
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// encode renders a value as JSON for messages
+func encode(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// joinPath appends a property name to a path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)(\.(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?))*$`)
+)
+
+// formats checks the values of the format keyword. Unknown formats are
+// accepted, as the specification allows.
+var formats = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05.999999999Z07:00", s)
+		return err == nil
+	},
+	"email": func(s string) bool {
+		address, err := mail.ParseAddress(s)
+		return err == nil && address.Address == s
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"uuid": uuidPattern.MatchString,
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && strings.Count(s, ".") == 3
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnamePattern.MatchString(s)
+	},
+}
@@ -0,0 +1,274 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Content types
+const (
+	ContentTypeText         = "text"
+	ContentTypeImage        = "image"
+	ContentTypeAudio        = "audio"
+	ContentTypeResource     = "resource"
+	ContentTypeResourceLink = "resource_link"
+)
+
+// Content is a single item of tool result, prompt or sampling content.
+//
+// Which fields are used depends on Type:
+//   - "text": Text
+//   - "image" and "audio": Data (base64) and MimeType
+//   - "resource": Resource, an embedded resource with text or blob contents
+//   - "resource_link": URI, Name, Description, MimeType and Size
+//
+// Content stays a single struct so that existing code building Content
+// literals keeps working; the As* methods return typed views of the variants,
+// and the New* functions build them.
+//
+// Only the fields belonging to Type are written when Content is marshalled.
+// Content of a type this package does not know keeps Type and the fields
+// above; any other fields are dropped.
+type Content struct {
+	Type        string                 `json:"type"`
+	Text        string                 `json:"text,omitempty"`
+	Data        string                 `json:"data,omitempty"`
+	MimeType    string                 `json:"mimeType,omitempty"`
+	Resource    *ResourceContents      `json:"resource,omitempty"`
+	URI         string                 `json:"uri,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Size        int64                  `json:"size,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+	Meta        map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// TextContent is plain text content
+type TextContent struct {
+	Text        string
+	Annotations map[string]interface{}
+}
+
+// ImageContent is a base64-encoded image
+type ImageContent struct {
+	Data        string
+	MimeType    string
+	Annotations map[string]interface{}
+}
+
+// AudioContent is base64-encoded audio
+type AudioContent struct {
+	Data        string
+	MimeType    string
+	Annotations map[string]interface{}
+}
+
+// EmbeddedResource is a resource whose contents are included inline
+type EmbeddedResource struct {
+	Resource    ResourceContents
+	Annotations map[string]interface{}
+}
+
+// ResourceLink points to a resource the client can read with resources/read
+type ResourceLink struct {
+	URI         string
+	Name        string
+	Description string
+	MimeType    string
+	Size        int64
+	Annotations map[string]interface{}
+}
+
+// NewTextContent returns text content
+func NewTextContent(text string) Content {
+	return Content{Type: ContentTypeText, Text: text}
+}
+
+// NewImageContent returns image content holding data encoded as base64
+func NewImageContent(data []byte, mimeType string) Content {
+	return Content{Type: ContentTypeImage, Data: base64.StdEncoding.EncodeToString(data), MimeType: mimeType}
+}
+
+// NewAudioContent returns audio content holding data encoded as base64
+func NewAudioContent(data []byte, mimeType string) Content {
+	return Content{Type: ContentTypeAudio, Data: base64.StdEncoding.EncodeToString(data), MimeType: mimeType}
+}
+
+// NewEmbeddedResource returns content embedding the given resource contents
+func NewEmbeddedResource(resource ResourceContents) Content {
+	return Content{Type: ContentTypeResource, Resource: &resource}
+}
+
+// NewResourceLink returns content linking to the resource at uri
+func NewResourceLink(uri, name string) Content {
+	return Content{Type: ContentTypeResourceLink, URI: uri, Name: name}
+}
+
+// AsText returns the text variant if c is text content
+func (c Content) AsText() (TextContent, bool) {
+	if c.Type != ContentTypeText {
+		return TextContent{}, false
+	}
+	return TextContent{Text: c.Text, Annotations: c.Annotations}, true
+}
+
+// AsImage returns the image variant if c is image content
+func (c Content) AsImage() (ImageContent, bool) {
+	if c.Type != ContentTypeImage {
+		return ImageContent{}, false
+	}
+	return ImageContent{Data: c.Data, MimeType: c.MimeType, Annotations: c.Annotations}, true
+}
+
+// AsAudio returns the audio variant if c is audio content
+func (c Content) AsAudio() (AudioContent, bool) {
+	if c.Type != ContentTypeAudio {
+		return AudioContent{}, false
+	}
+	return AudioContent{Data: c.Data, MimeType: c.MimeType, Annotations: c.Annotations}, true
+}
+
+// AsEmbeddedResource returns the embedded resource variant if c is resource content
+func (c Content) AsEmbeddedResource() (EmbeddedResource, bool) {
+	if c.Type != ContentTypeResource || c.Resource == nil {
+		return EmbeddedResource{}, false
+	}
+	return EmbeddedResource{Resource: *c.Resource, Annotations: c.Annotations}, true
+}
+
+// AsResourceLink returns the resource link variant if c is resource_link content
+func (c Content) AsResourceLink() (ResourceLink, bool) {
+	if c.Type != ContentTypeResourceLink {
+		return ResourceLink{}, false
+	}
+	return ResourceLink{
+		URI:         c.URI,
+		Name:        c.Name,
+		Description: c.Description,
+		MimeType:    c.MimeType,
+		Size:        c.Size,
+		Annotations: c.Annotations,
+	}, true
+}
+
+// DecodeBlob decodes the binary payload of image, audio or embedded blob
+// resource content
+func (c Content) DecodeBlob() ([]byte, error) {
+	switch c.Type {
+	case ContentTypeImage, ContentTypeAudio:
+		return base64.StdEncoding.DecodeString(c.Data)
+	case ContentTypeResource:
+		if c.Resource == nil {
+			return nil, fmt.Errorf("resource content has no resource")
+		}
+		return c.Resource.DecodeBlob()
+	default:
+		return nil, fmt.Errorf("%s content has no binary data", c.Type)
+	}
+}
+
+// MarshalJSON writes only the fields that belong to the content type
+func (c Content) MarshalJSON() ([]byte, error) {
+	switch c.Type {
+	case ContentTypeText:
+		return json.Marshal(struct {
+			Type        string                 `json:"type"`
+			Text        string                 `json:"text"`
+			Annotations map[string]interface{} `json:"annotations,omitempty"`
+			Meta        map[string]interface{} `json:"_meta,omitempty"`
+		}{c.Type, c.Text, c.Annotations, c.Meta})
+
+	case ContentTypeImage, ContentTypeAudio:
+		return json.Marshal(struct {
+			Type        string                 `json:"type"`
+			Data        string                 `json:"data"`
+			MimeType    string                 `json:"mimeType"`
+			Annotations map[string]interface{} `json:"annotations,omitempty"`
+			Meta        map[string]interface{} `json:"_meta,omitempty"`
+		}{c.Type, c.Data, c.MimeType, c.Annotations, c.Meta})
+
+	case ContentTypeResource:
+		return json.Marshal(struct {
+			Type        string                 `json:"type"`
+			Resource    *ResourceContents      `json:"resource"`
+			Annotations map[string]interface{} `json:"annotations,omitempty"`
+			Meta        map[string]interface{} `json:"_meta,omitempty"`
+		}{c.Type, c.Resource, c.Annotations, c.Meta})
+
+	case ContentTypeResourceLink:
+		return json.Marshal(struct {
+			Type        string                 `json:"type"`
+			URI         string                 `json:"uri"`
+			Name        string                 `json:"name"`
+			Description string                 `json:"description,omitempty"`
+			MimeType    string                 `json:"mimeType,omitempty"`
+			Size        int64                  `json:"size,omitempty"`
+			Annotations map[string]interface{} `json:"annotations,omitempty"`
+			Meta        map[string]interface{} `json:"_meta,omitempty"`
+		}{c.Type, c.URI, c.Name, c.Description, c.MimeType, c.Size, c.Annotations, c.Meta})
+	}
+
+	// plain has no MarshalJSON method, so this does not recurse
+	type plain Content
+	return json.Marshal(plain(c))
+}
+
+// UnmarshalJSON decodes any content type and checks that resource content
+// carries its resource
+func (c *Content) UnmarshalJSON(data []byte) error {
+	type plain Content
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*c = Content(decoded)
+
+	if c.Type == ContentTypeResource && c.Resource == nil {
+		return fmt.Errorf("resource content is missing the resource field")
+	}
+	return nil
+}
+
+// ResourceContents holds the contents of a resource as returned by
+// resources/read or embedded in tool results. A resource is either text
+// (Text) or binary data encoded as base64 (Blob).
+type ResourceContents struct {
+	URI      string                 `json:"uri"`
+	MimeType string                 `json:"mimeType,omitempty"`
+	Text     string                 `json:"text,omitempty"`
+	Blob     string                 `json:"blob,omitempty"`
+	Meta     map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// MarshalJSON writes blob for binary resources and text otherwise, so text
+// resources keep their text field even when it is empty
+func (r ResourceContents) MarshalJSON() ([]byte, error) {
+	if r.IsBlob() {
+		return json.Marshal(struct {
+			URI      string                 `json:"uri"`
+			MimeType string                 `json:"mimeType,omitempty"`
+			Blob     string                 `json:"blob"`
+			Meta     map[string]interface{} `json:"_meta,omitempty"`
+		}{r.URI, r.MimeType, r.Blob, r.Meta})
+	}
+	return json.Marshal(struct {
+		URI      string                 `json:"uri"`
+		MimeType string                 `json:"mimeType,omitempty"`
+		Text     string                 `json:"text"`
+		Meta     map[string]interface{} `json:"_meta,omitempty"`
+	}{r.URI, r.MimeType, r.Text, r.Meta})
+}
+
+// IsBlob reports whether the resource holds binary data
+func (r ResourceContents) IsBlob() bool {
+	return r.Blob != ""
+}
+
+// DecodeBlob decodes the base64 blob of a binary resource
+func (r ResourceContents) DecodeBlob() ([]byte, error) {
+	if !r.IsBlob() {
+		return nil, fmt.Errorf("resource %s has no blob", r.URI)
+	}
+	return base64.StdEncoding.DecodeString(r.Blob)
+}
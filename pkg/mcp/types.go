@@ -1,11 +1,85 @@
 package mcp
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
-// MCP Protocol Version
-const Version = "2024-11-05"
+// MCP protocol versions supported by this package
+const (
+	ProtocolVersion20241105 = "2024-11-05"
+	ProtocolVersion20250326 = "2025-03-26"
+	ProtocolVersion20250618 = "2025-06-18"
+)
+
+// Version is the newest MCP protocol version supported by this package. It is
+// the version a client offers during initialization.
+const Version = ProtocolVersion20250618
+
+// SupportedProtocolVersions lists every supported protocol version, newest first
+var SupportedProtocolVersions = []string{
+	ProtocolVersion20250618,
+	ProtocolVersion20250326,
+	ProtocolVersion20241105,
+}
+
+// IsSupportedProtocolVersion reports whether version is one of
+// SupportedProtocolVersions
+func IsSupportedProtocolVersion(version string) bool {
+	for _, supported := range SupportedProtocolVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Feature identifies protocol functionality that only exists from a given
+// protocol version onwards
+type Feature string
+
+const (
+	FeatureAudioContent      Feature = "audioContent"
+	FeatureCompletions       Feature = "completions"
+	FeatureToolAnnotations   Feature = "toolAnnotations"
+	FeatureProgressMessages  Feature = "progressMessages"
+	FeatureStructuredContent Feature = "structuredContent"
+	FeatureElicitation       Feature = "elicitation"
+	FeatureResourceLinks     Feature = "resourceLinks"
+	FeatureBatching          Feature = "batching"
+)
+
+// featureVersions maps each feature to the protocol version that introduced it
+var featureVersions = map[Feature]string{
+	FeatureAudioContent:      ProtocolVersion20250326,
+	FeatureCompletions:       ProtocolVersion20250326,
+	FeatureToolAnnotations:   ProtocolVersion20250326,
+	FeatureProgressMessages:  ProtocolVersion20250326,
+	FeatureStructuredContent: ProtocolVersion20250618,
+	FeatureElicitation:       ProtocolVersion20250618,
+	FeatureResourceLinks:     ProtocolVersion20250618,
+	FeatureBatching:          ProtocolVersion20250326,
+}
+
+// featureRemovals maps features that were dropped again to the protocol
+// version that removed them
+var featureRemovals = map[Feature]string{
+	FeatureBatching: ProtocolVersion20250618,
+}
+
+// SupportsFeature reports whether protocol version includes feature, taking
+// features that were removed again into account. Protocol versions are dates,
+// so they compare correctly as strings.
+func SupportsFeature(version string, feature Feature) bool {
+	introduced, ok := featureVersions[feature]
+	if !ok || !IsSupportedProtocolVersion(version) {
+		return false
+	}
+	if removed, ok := featureRemovals[feature]; ok && version >= removed {
+		return false
+	}
+	return version >= introduced
+}
 
 // Message Types
 type MessageType string
@@ -36,20 +110,26 @@ type ErrorInfo struct {
 // Client Capabilities
 type ClientCapabilities struct {
 	Experimental map[string]interface{} `json:"experimental,omitempty"`
+	Roots        *RootsCapability       `json:"roots,omitempty"`
 	Sampling     *SamplingCapability    `json:"sampling,omitempty"`
 }
 
+type RootsCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
 type SamplingCapability struct{}
 
 // Server Capabilities
 type ServerCapabilities struct {
 	Experimental map[string]interface{} `json:"experimental,omitempty"`
+	Completions  *CompletionsCapability `json:"completions,omitempty"`
 	Logging      *LoggingCapability     `json:"logging,omitempty"`
 	Prompts      *PromptsCapability     `json:"prompts,omitempty"`
 	Resources    *ResourcesCapability   `json:"resources,omitempty"`
 	Tools        *ToolsCapability       `json:"tools,omitempty"`
 }
 
+type CompletionsCapability struct{}
 type LoggingCapability struct{}
 type PromptsCapability struct {
 	ListChanged bool `json:"listChanged,omitempty"`
@@ -87,37 +167,90 @@ type ServerInfo struct {
 
 // Tool Definitions
 type Tool struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description,omitempty"`
-	InputSchema map[string]interface{} `json:"inputSchema"`
+	Name         string                 `json:"name"`
+	Title        string                 `json:"title,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	InputSchema  map[string]interface{} `json:"inputSchema"`
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"`
+	Annotations  *ToolAnnotations       `json:"annotations,omitempty"`
+}
+
+// DisplayName returns the tool's title, falling back to the annotation
+// title and then to its name
+func (t Tool) DisplayName() string {
+	if t.Title != "" {
+		return t.Title
+	}
+	if t.Annotations != nil && t.Annotations.Title != "" {
+		return t.Annotations.Title
+	}
+	return t.Name
+}
+
+// ToolAnnotations describe a tool's behavior. They are hints supplied by the
+// server and must not be relied on for security decisions. Unset hints take
+// the defaults defined by the specification, which the Is* methods apply.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
+}
+
+// IsReadOnly reports whether the tool does not modify its environment.
+// Defaults to false.
+func (a *ToolAnnotations) IsReadOnly() bool {
+	return a != nil && a.ReadOnlyHint != nil && *a.ReadOnlyHint
+}
+
+// IsDestructive reports whether the tool may perform destructive updates.
+// Defaults to true, and is always false for read-only tools.
+func (a *ToolAnnotations) IsDestructive() bool {
+	if a.IsReadOnly() {
+		return false
+	}
+	return a == nil || a.DestructiveHint == nil || *a.DestructiveHint
 }
 
-type ListToolsRequest struct{}
+// IsIdempotent reports whether calling the tool repeatedly with the same
+// arguments has no additional effect. Defaults to false.
+func (a *ToolAnnotations) IsIdempotent() bool {
+	return a != nil && a.IdempotentHint != nil && *a.IdempotentHint
+}
+
+// IsOpenWorld reports whether the tool interacts with external entities.
+// Defaults to true.
+func (a *ToolAnnotations) IsOpenWorld() bool {
+	return a == nil || a.OpenWorldHint == nil || *a.OpenWorldHint
+}
+
+type ListToolsRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+}
 
 type ListToolsResponse struct {
-	Tools []Tool `json:"tools"`
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// RequestMeta carries protocol-level metadata attached to a request
+type RequestMeta struct {
+	ProgressToken interface{} `json:"progressToken,omitempty"`
 }
 
 // Call Tool Request/Response
 type CallToolRequest struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
 }
 
 type CallToolResponse struct {
 	Content []Content `json:"content"`
-	IsError bool      `json:"isError,omitempty"`
-}
-
-// Content Types
-type Content struct {
-	Type        string                 `json:"type"`
-	Text        string                 `json:"text,omitempty"`
-	Data        string                 `json:"data,omitempty"`
-	MimeType    string                 `json:"mimeType,omitempty"`
-	Name        string                 `json:"name,omitempty"`
-	URI         string                 `json:"uri,omitempty"`
-	Annotations map[string]interface{} `json:"annotations,omitempty"`
+	// StructuredContent holds a result matching the tool's OutputSchema
+	StructuredContent map[string]interface{} `json:"structuredContent,omitempty"`
+	IsError           bool                   `json:"isError,omitempty"`
 }
 
 // Resource Definitions
@@ -129,10 +262,31 @@ type Resource struct {
 	Annotations map[string]interface{} `json:"annotations,omitempty"`
 }
 
-type ListResourcesRequest struct{}
+type ListResourcesRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+}
 
 type ListResourcesResponse struct {
-	Resources []Resource `json:"resources"`
+	Resources  []Resource `json:"resources"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// Resource Template Definitions
+type ResourceTemplate struct {
+	URITemplate string                 `json:"uriTemplate"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	MimeType    string                 `json:"mimeType,omitempty"`
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+}
+
+type ListResourceTemplatesRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type ListResourceTemplatesResponse struct {
+	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+	NextCursor        string             `json:"nextCursor,omitempty"`
 }
 
 // Prompt Definitions
@@ -148,10 +302,13 @@ type PromptArgument struct {
 	Required    bool   `json:"required,omitempty"`
 }
 
-type ListPromptsRequest struct{}
+type ListPromptsRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+}
 
 type ListPromptsResponse struct {
-	Prompts []Prompt `json:"prompts"`
+	Prompts    []Prompt `json:"prompts"`
+	NextCursor string   `json:"nextCursor,omitempty"`
 }
 
 // GetPrompt request/response types
@@ -176,7 +333,193 @@ type ReadResourceRequest struct {
 }
 
 type ReadResourceResponse struct {
-	Contents []Content `json:"contents"`
+	Contents []ResourceContents `json:"contents"`
+}
+
+// Subscribe/Unsubscribe request types
+type SubscribeRequest struct {
+	URI string `json:"uri"`
+}
+
+type UnsubscribeRequest struct {
+	URI string `json:"uri"`
+}
+
+// Completion request/response types
+type CompletionReference struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// NewPromptReference references a prompt whose arguments should be completed
+func NewPromptReference(name string) CompletionReference {
+	return CompletionReference{Type: "ref/prompt", Name: name}
+}
+
+// NewResourceReference references a resource template whose variables should
+// be completed
+func NewResourceReference(uriTemplate string) CompletionReference {
+	return CompletionReference{Type: "ref/resource", URI: uriTemplate}
+}
+
+type CompletionArgument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CompleteRequest struct {
+	Ref      CompletionReference `json:"ref"`
+	Argument CompletionArgument  `json:"argument"`
+}
+
+type Completion struct {
+	Values  []string `json:"values"`
+	Total   int      `json:"total,omitempty"`
+	HasMore bool     `json:"hasMore,omitempty"`
+}
+
+type CompleteResponse struct {
+	Completion Completion `json:"completion"`
+}
+
+// Sampling request/response types
+type SamplingMessage struct {
+	Role    string  `json:"role"`
+	Content Content `json:"content"`
+}
+
+type ModelHint struct {
+	Name string `json:"name,omitempty"`
+}
+
+type ModelPreferences struct {
+	Hints                []ModelHint `json:"hints,omitempty"`
+	CostPriority         float64     `json:"costPriority,omitempty"`
+	SpeedPriority        float64     `json:"speedPriority,omitempty"`
+	IntelligencePriority float64     `json:"intelligencePriority,omitempty"`
+}
+
+type CreateMessageRequest struct {
+	Messages         []SamplingMessage      `json:"messages"`
+	ModelPreferences *ModelPreferences      `json:"modelPreferences,omitempty"`
+	SystemPrompt     string                 `json:"systemPrompt,omitempty"`
+	IncludeContext   string                 `json:"includeContext,omitempty"`
+	Temperature      *float64               `json:"temperature,omitempty"`
+	MaxTokens        int                    `json:"maxTokens"`
+	StopSequences    []string               `json:"stopSequences,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type CreateMessageResult struct {
+	Role       string  `json:"role"`
+	Content    Content `json:"content"`
+	Model      string  `json:"model"`
+	StopReason string  `json:"stopReason,omitempty"`
+}
+
+// Root Definitions
+type Root struct {
+	URI  string `json:"uri"`
+	Name string `json:"name,omitempty"`
+}
+
+type ListRootsResult struct {
+	Roots []Root `json:"roots"`
+}
+
+// Notification methods sent by clients
+const (
+	NotificationInitialized      = "notifications/initialized"
+	NotificationRootsListChanged = "notifications/roots/list_changed"
+	NotificationCancelled        = "notifications/cancelled"
+)
+
+// CancelledNotification tells the other side to stop working on a request
+type CancelledNotification struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// Notification methods sent by servers
+const (
+	NotificationToolsListChanged     = "notifications/tools/list_changed"
+	NotificationResourcesListChanged = "notifications/resources/list_changed"
+	NotificationResourcesUpdated     = "notifications/resources/updated"
+	NotificationPromptsListChanged   = "notifications/prompts/list_changed"
+	NotificationMessage              = "notifications/message"
+	NotificationProgress             = "notifications/progress"
+)
+
+// ResourceUpdatedNotification is sent when a subscribed resource changes
+type ResourceUpdatedNotification struct {
+	URI string `json:"uri"`
+}
+
+// LoggingLevel is a log severity as defined by RFC 5424 (syslog)
+type LoggingLevel string
+
+const (
+	LoggingLevelDebug     LoggingLevel = "debug"
+	LoggingLevelInfo      LoggingLevel = "info"
+	LoggingLevelNotice    LoggingLevel = "notice"
+	LoggingLevelWarning   LoggingLevel = "warning"
+	LoggingLevelError     LoggingLevel = "error"
+	LoggingLevelCritical  LoggingLevel = "critical"
+	LoggingLevelAlert     LoggingLevel = "alert"
+	LoggingLevelEmergency LoggingLevel = "emergency"
+)
+
+// LoggingLevels lists all logging levels from least to most severe
+var LoggingLevels = []LoggingLevel{
+	LoggingLevelDebug,
+	LoggingLevelInfo,
+	LoggingLevelNotice,
+	LoggingLevelWarning,
+	LoggingLevelError,
+	LoggingLevelCritical,
+	LoggingLevelAlert,
+	LoggingLevelEmergency,
+}
+
+// Severity returns the position of the level in LoggingLevels, so higher
+// values are more severe. Unknown levels return -1.
+func (l LoggingLevel) Severity() int {
+	for i, level := range LoggingLevels {
+		if level == l {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseLoggingLevel converts a level name such as "warning" to a LoggingLevel
+func ParseLoggingLevel(name string) (LoggingLevel, error) {
+	level := LoggingLevel(name)
+	if level.Severity() < 0 {
+		return "", fmt.Errorf("unknown logging level %q", name)
+	}
+	return level, nil
+}
+
+// SetLevelRequest asks the server to send log messages at or above Level
+type SetLevelRequest struct {
+	Level LoggingLevel `json:"level"`
+}
+
+// LoggingMessageNotification carries a log message emitted by the server
+type LoggingMessageNotification struct {
+	Level  LoggingLevel `json:"level"`
+	Logger string       `json:"logger,omitempty"`
+	Data   interface{}  `json:"data"`
+}
+
+// ProgressNotification reports progress on a long-running request
+type ProgressNotification struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         float64     `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
 }
 
 // Utility functions for creating messages
@@ -217,6 +560,46 @@ func NewNotification(method string, params interface{}) *Message {
 	}
 }
 
+// SetMeta sets a field of the message's params._meta object, such as an
+// authorization token or a trace context. Params are converted to a JSON
+// object first, so they must encode to an object or be empty. Their values
+// are kept as raw JSON, so numbers and other fields pass through unchanged.
+func (m *Message) SetMeta(key string, value interface{}) error {
+	params := map[string]json.RawMessage{}
+	if m.Params != nil {
+		data, err := json.Marshal(m.Params)
+		if err != nil {
+			return fmt.Errorf("failed to encode params: %w", err)
+		}
+		if err := json.Unmarshal(data, &params); err != nil {
+			return fmt.Errorf("params of %s are not an object: %w", m.Method, err)
+		}
+		if params == nil {
+			params = map[string]json.RawMessage{}
+		}
+	}
+
+	var meta map[string]json.RawMessage
+	if raw, ok := params["_meta"]; ok {
+		// A _meta that is not an object is replaced
+		json.Unmarshal(raw, &meta)
+	}
+	if meta == nil {
+		meta = map[string]json.RawMessage{}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode _meta.%s: %w", key, err)
+	}
+	meta[key] = encoded
+	if params["_meta"], err = json.Marshal(meta); err != nil {
+		return fmt.Errorf("failed to encode _meta: %w", err)
+	}
+	m.Params = params
+	return nil
+}
+
 // Error codes based on JSON-RPC 2.0 and MCP specification
 const (
 	ErrorCodeParseError     = -32700
@@ -24,6 +24,7 @@ type StdioTransport struct {
 	writer    *bufio.Writer
 	connected bool
 	mu        sync.RWMutex
+	batch     batchBuffer
 }
 
 // NewStdioTransport creates a new STDIO transport
@@ -117,6 +118,7 @@ func (s *StdioTransport) Close() error {
 	s.stderr = nil
 	s.reader = nil
 	s.writer = nil
+	s.batch.reset()
 
 	if len(errs) > 0 {
 		return fmt.Errorf("errors during close: %v", errs)
@@ -139,8 +141,29 @@ func (s *StdioTransport) Send(message *mcp.Message) error {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	// Write message with newline delimiter
-	_, err = s.writer.Write(append(data, '\n'))
+	return s.writeLine(data)
+}
+
+// SendBatch sends several messages as one batch frame
+func (s *StdioTransport) SendBatch(messages []*mcp.Message) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.connected {
+		return fmt.Errorf("transport not connected")
+	}
+
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	return s.writeLine(data)
+}
+
+// writeLine writes a newline delimited frame. s.mu must be held.
+func (s *StdioTransport) writeLine(data []byte) error {
+	_, err := s.writer.Write(append(data, '\n'))
 	if err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
@@ -148,26 +171,36 @@ func (s *StdioTransport) Send(message *mcp.Message) error {
 	return s.writer.Flush()
 }
 
-// Receive receives a message from STDIO
+// Receive receives a message from STDIO.
 func (s *StdioTransport) Receive() (*mcp.Message, error) {
+	return s.batch.receive(s.readFrame)
+}
+
+// ReceiveFrame receives the next message, or every message of a batch frame
+func (s *StdioTransport) ReceiveFrame() ([]*mcp.Message, error) {
+	return s.batch.receiveFrame(s.readFrame)
+}
+
+// readFrame reads and decodes one line.
+//
+// The lock is only held while grabbing the reader so that Close can interrupt
+// a blocked read by closing the process pipes.
+func (s *StdioTransport) readFrame() ([]*mcp.Message, error) {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	reader := s.reader
+	connected := s.connected
+	s.mu.RUnlock()
 
-	if !s.connected {
+	if !connected || reader == nil {
 		return nil, fmt.Errorf("transport not connected")
 	}
 
-	line, err := s.reader.ReadBytes('\n')
+	line, err := reader.ReadBytes('\n')
 	if err != nil {
 		return nil, fmt.Errorf("failed to read message: %w", err)
 	}
 
-	var message mcp.Message
-	if err := json.Unmarshal(line, &message); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
-	}
-
-	return &message, nil
+	return decodeFrame(line)
 }
 
 // GetReader returns the stdout reader
@@ -23,6 +23,7 @@ type TCPTransport struct {
 	connected bool
 	mu        sync.RWMutex
 	timeout   time.Duration
+	batch     batchBuffer
 }
 
 // NewTCPTransport creates a new TCP transport
@@ -76,6 +77,7 @@ func (t *TCPTransport) Close() error {
 	t.conn = nil
 	t.reader = nil
 	t.writer = nil
+	t.batch.reset()
 
 	return err
 }
@@ -94,8 +96,29 @@ func (t *TCPTransport) Send(message *mcp.Message) error {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	// Write message with newline delimiter
-	_, err = t.writer.Write(append(data, '\n'))
+	return t.writeLine(data)
+}
+
+// SendBatch sends several messages as one batch frame
+func (t *TCPTransport) SendBatch(messages []*mcp.Message) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.connected {
+		return fmt.Errorf("transport not connected")
+	}
+
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	return t.writeLine(data)
+}
+
+// writeLine writes a newline delimited frame. t.mu must be held.
+func (t *TCPTransport) writeLine(data []byte) error {
+	_, err := t.writer.Write(append(data, '\n'))
 	if err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
@@ -108,26 +131,36 @@ func (t *TCPTransport) Send(message *mcp.Message) error {
 	return nil
 }
 
-// Receive receives a message from TCP
+// Receive receives a message from TCP.
 func (t *TCPTransport) Receive() (*mcp.Message, error) {
+	return t.batch.receive(t.readFrame)
+}
+
+// ReceiveFrame receives the next message, or every message of a batch frame
+func (t *TCPTransport) ReceiveFrame() ([]*mcp.Message, error) {
+	return t.batch.receiveFrame(t.readFrame)
+}
+
+// readFrame reads and decodes one line.
+//
+// The lock is only held while grabbing the reader so that Close can interrupt
+// a blocked read by closing the underlying connection.
+func (t *TCPTransport) readFrame() ([]*mcp.Message, error) {
 	t.mu.RLock()
-	defer t.mu.RUnlock()
+	reader := t.reader
+	connected := t.connected
+	t.mu.RUnlock()
 
-	if !t.connected {
+	if !connected || reader == nil {
 		return nil, fmt.Errorf("transport not connected")
 	}
 
-	line, err := t.reader.ReadBytes('\n')
+	line, err := reader.ReadBytes('\n')
 	if err != nil {
 		return nil, fmt.Errorf("failed to read message: %w", err)
 	}
 
-	var message mcp.Message
-	if err := json.Unmarshal(line, &message); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
-	}
-
-	return &message, nil
+	return decodeFrame(line)
 }
 
 // GetReader returns the underlying reader
@@ -1,12 +1,22 @@
 package transport
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"sync"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
 )
 
+// ErrReceiveTimeout is returned by Receive when no message arrived within the
+// transport's read timeout. The connection is still usable and callers may
+// simply call Receive again.
+var ErrReceiveTimeout = errors.New("timeout receiving message")
+
 // Transport represents a communication transport for MCP protocol
 type Transport interface {
 	// Connect establishes the connection
@@ -18,7 +28,9 @@ type Transport interface {
 	// Send sends a message to the server
 	Send(message *mcp.Message) error
 
-	// Receive receives a message from the server
+	// Receive receives a message from the server. It may block until a
+	// message arrives and must return an error once Close has been called.
+	// The messages of a batch frame are returned one at a time.
 	Receive() (*mcp.Message, error)
 
 	// GetReader returns the underlying reader
@@ -30,3 +42,105 @@ type Transport interface {
 	// IsConnected returns true if the transport is connected
 	IsConnected() bool
 }
+
+// BatchSender is implemented by transports that can send several messages as
+// one JSON-RPC batch frame
+type BatchSender interface {
+	SendBatch(messages []*mcp.Message) error
+}
+
+// FrameReceiver is implemented by transports that can return the messages of
+// a batch frame together, so a batch of requests can be answered with one
+// batch response
+type FrameReceiver interface {
+	// ReceiveFrame receives the next frame: a single message, or every
+	// message of a batch. Messages of a batch partly returned by Receive are
+	// returned first.
+	ReceiveFrame() ([]*mcp.Message, error)
+}
+
+// DecodeFrame decodes a frame holding either a single JSON-RPC message or a
+// batch array of messages
+func DecodeFrame(frame []byte) ([]*mcp.Message, error) {
+	trimmed := bytes.TrimSpace(frame)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		var message mcp.Message
+		if err := json.Unmarshal(trimmed, &message); err != nil {
+			return nil, err
+		}
+		return []*mcp.Message{&message}, nil
+	}
+
+	var messages []*mcp.Message
+	if err := json.Unmarshal(trimmed, &messages); err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, errors.New("empty batch")
+	}
+	for i, message := range messages {
+		if message == nil {
+			return nil, fmt.Errorf("batch item %d is null", i)
+		}
+	}
+	return messages, nil
+}
+
+// batchBuffer holds the messages of a received batch frame that Receive has
+// not returned yet
+type batchBuffer struct {
+	mu       sync.Mutex
+	messages []*mcp.Message
+}
+
+// receive returns the next buffered message, or reads a frame with readFrame,
+// returning its first message and buffering the rest
+func (b *batchBuffer) receive(readFrame func() ([]*mcp.Message, error)) (*mcp.Message, error) {
+	b.mu.Lock()
+	if len(b.messages) > 0 {
+		message := b.messages[0]
+		b.messages = b.messages[1:]
+		b.mu.Unlock()
+		return message, nil
+	}
+	b.mu.Unlock()
+
+	messages, err := readFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.messages = append(b.messages, messages[1:]...)
+	b.mu.Unlock()
+	return messages[0], nil
+}
+
+// receiveFrame returns the buffered messages, or reads a frame with readFrame
+func (b *batchBuffer) receiveFrame(readFrame func() ([]*mcp.Message, error)) ([]*mcp.Message, error) {
+	b.mu.Lock()
+	messages := b.messages
+	b.messages = nil
+	b.mu.Unlock()
+
+	if len(messages) > 0 {
+		return messages, nil
+	}
+	return readFrame()
+}
+
+// reset drops the buffered messages of a closed connection
+func (b *batchBuffer) reset() {
+	b.mu.Lock()
+	b.messages = nil
+	b.mu.Unlock()
+}
+
+// decodeFrame is DecodeFrame with the error transports return
+func decodeFrame(frame []byte) ([]*mcp.Message, error) {
+	messages, err := DecodeFrame(frame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	return messages, nil
+}
@@ -25,6 +25,7 @@ type WebSocketTransport struct {
 	writeChan chan []byte
 	stopChan  chan struct{}
 	errorChan chan error
+	batch     batchBuffer
 }
 
 // NewWebSocketTransport creates a new WebSocket transport
@@ -65,12 +66,18 @@ func (w *WebSocketTransport) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to WebSocket %s: %w", w.url, err)
 	}
 
+	// Fresh channels per connection, so a reconnect never sees messages or
+	// errors left over from the previous one
 	w.conn = conn
+	w.readChan = make(chan []byte, 100)
+	w.writeChan = make(chan []byte, 100)
+	w.stopChan = make(chan struct{})
+	w.errorChan = make(chan error, 10)
 	w.connected = true
 
 	// Start goroutines for reading and writing
-	go w.readLoop()
-	go w.writeLoop()
+	go w.readLoop(conn, w.readChan, w.stopChan, w.errorChan)
+	go w.writeLoop(conn, w.writeChan, w.stopChan, w.errorChan)
 
 	return nil
 }
@@ -93,52 +100,86 @@ func (w *WebSocketTransport) Close() error {
 
 	w.connected = false
 	w.conn = nil
+	w.batch.reset()
 
 	return err
 }
 
 // Send sends a message over WebSocket
 func (w *WebSocketTransport) Send(message *mcp.Message) error {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	if !w.connected {
-		return fmt.Errorf("transport not connected")
-	}
-
 	data, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
+	return w.write(data)
+}
+
+// SendBatch sends several messages as one batch frame
+func (w *WebSocketTransport) SendBatch(messages []*mcp.Message) error {
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	return w.write(data)
+}
+
+// write queues a frame for the write loop
+func (w *WebSocketTransport) write(data []byte) error {
+	w.mu.RLock()
+	connected := w.connected
+	writeChan, stopChan, timeout := w.writeChan, w.stopChan, w.timeout
+	w.mu.RUnlock()
+
+	if !connected {
+		return fmt.Errorf("transport not connected")
+	}
+
 	select {
-	case w.writeChan <- data:
+	case writeChan <- data:
 		return nil
-	case <-time.After(w.timeout):
+	case <-stopChan:
+		return fmt.Errorf("transport closed")
+	case <-time.After(timeout):
 		return fmt.Errorf("timeout sending message")
 	}
 }
 
-// Receive receives a message from WebSocket
+// Receive receives a message from WebSocket.
+//
+// If nothing arrives within the configured timeout, ErrReceiveTimeout is
+// returned and the connection stays usable.
 func (w *WebSocketTransport) Receive() (*mcp.Message, error) {
+	return w.batch.receive(w.readFrame)
+}
+
+// ReceiveFrame receives the next message, or every message of a batch frame
+func (w *WebSocketTransport) ReceiveFrame() ([]*mcp.Message, error) {
+	return w.batch.receiveFrame(w.readFrame)
+}
+
+// readFrame waits for one WebSocket message and decodes it
+func (w *WebSocketTransport) readFrame() ([]*mcp.Message, error) {
 	w.mu.RLock()
-	defer w.mu.RUnlock()
+	connected := w.connected
+	timeout := w.timeout
+	readChan, stopChan, errorChan := w.readChan, w.stopChan, w.errorChan
+	w.mu.RUnlock()
 
-	if !w.connected {
+	if !connected {
 		return nil, fmt.Errorf("transport not connected")
 	}
 
 	select {
-	case data := <-w.readChan:
-		var message mcp.Message
-		if err := json.Unmarshal(data, &message); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal message: %w", err)
-		}
-		return &message, nil
-	case err := <-w.errorChan:
+	case data := <-readChan:
+		return decodeFrame(data)
+	case err := <-errorChan:
 		return nil, err
-	case <-time.After(w.timeout):
-		return nil, fmt.Errorf("timeout receiving message")
+	case <-stopChan:
+		return nil, fmt.Errorf("transport closed")
+	case <-time.After(timeout):
+		return nil, ErrReceiveTimeout
 	}
 }
 
@@ -166,50 +207,63 @@ func (w *WebSocketTransport) SetTimeout(timeout time.Duration) {
 	w.timeout = timeout
 }
 
-// readLoop handles reading messages from WebSocket
-func (w *WebSocketTransport) readLoop() {
+// readLoop handles reading messages from a single WebSocket connection
+func (w *WebSocketTransport) readLoop(conn *websocket.Conn, readChan chan<- []byte, stopChan <-chan struct{}, errorChan chan<- error) {
 	defer func() {
 		if r := recover(); r != nil {
-			w.errorChan <- fmt.Errorf("read loop panic: %v", r)
+			reportError(errorChan, fmt.Errorf("read loop panic: %v", r))
 		}
 	}()
 
 	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-stopChan:
+				// Closed by Close; nobody is waiting for the error
+			default:
+				reportError(errorChan, fmt.Errorf("failed to read WebSocket message: %w", err))
+			}
+			return
+		}
+
 		select {
-		case <-w.stopChan:
+		case readChan <- message:
+		case <-stopChan:
 			return
-		default:
-			_, message, err := w.conn.ReadMessage()
-			if err != nil {
-				w.errorChan <- fmt.Errorf("failed to read WebSocket message: %w", err)
-				return
-			}
-			w.readChan <- message
 		}
 	}
 }
 
-// writeLoop handles writing messages to WebSocket
-func (w *WebSocketTransport) writeLoop() {
+// writeLoop handles writing messages to a single WebSocket connection
+func (w *WebSocketTransport) writeLoop(conn *websocket.Conn, writeChan <-chan []byte, stopChan <-chan struct{}, errorChan chan<- error) {
 	defer func() {
 		if r := recover(); r != nil {
-			w.errorChan <- fmt.Errorf("write loop panic: %v", r)
+			reportError(errorChan, fmt.Errorf("write loop panic: %v", r))
 		}
 	}()
 
 	for {
 		select {
-		case <-w.stopChan:
+		case <-stopChan:
 			return
-		case data := <-w.writeChan:
-			if err := w.conn.WriteMessage(websocket.TextMessage, data); err != nil {
-				w.errorChan <- fmt.Errorf("failed to write WebSocket message: %w", err)
+		case data := <-writeChan:
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				reportError(errorChan, fmt.Errorf("failed to write WebSocket message: %w", err))
 				return
 			}
 		}
 	}
 }
 
+// reportError queues err for Receive without blocking if the buffer is full
+func reportError(errorChan chan<- error, err error) {
+	select {
+	case errorChan <- err:
+	default:
+	}
+}
+
 // GetURL returns the WebSocket URL
 func (w *WebSocketTransport) GetURL() string {
 	return w.url
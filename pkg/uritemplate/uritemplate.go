@@ -0,0 +1,361 @@
+// Package uritemplate implements URI Template expansion as defined by
+// RFC 6570, levels 1 through 4.
+//
+// MCP resource templates such as "file:///{+path}" or
+// "db://{schema}/tables{?limit,offset}" are expanded into concrete resource
+// URIs with Expand:
+//
+//	uri, err := uritemplate.Expand("file:///{+path}", map[string]interface{}{
+//		"path": "src/main.go",
+//	})
+//	// uri == "file:///src/main.go"
+//
+// Variable values may be strings (or anything printable with fmt), lists
+// ([]string or []interface{}) and associative arrays (map[string]string or
+// map[string]interface{}). Map keys are expanded in sorted order.
+package uritemplate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// operator describes how an expression is expanded (RFC 6570 Appendix A)
+type operator struct {
+	first         string
+	sep           string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var operators = map[byte]operator{
+	'+': {first: "", sep: ",", allowReserved: true},
+	'#': {first: "#", sep: ",", allowReserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+var simpleOperator = operator{first: "", sep: ","}
+
+// varSpec is a single variable reference inside an expression
+type varSpec struct {
+	name    string
+	explode bool
+	prefix  int
+}
+
+// Expand expands template using vars. Variables that are missing, nil or
+// empty collections are treated as undefined and omitted.
+func Expand(template string, vars map[string]interface{}) (string, error) {
+	var result strings.Builder
+
+	for i := 0; i < len(template); {
+		open := strings.IndexByte(template[i:], '{')
+		if open < 0 {
+			result.WriteString(template[i:])
+			break
+		}
+		result.WriteString(template[i : i+open])
+
+		start := i + open + 1
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed expression at offset %d", i+open)
+		}
+
+		expanded, err := expandExpression(template[start:start+end], vars)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(expanded)
+		i = start + end + 1
+	}
+
+	return result.String(), nil
+}
+
+// Variables returns the names of the variables referenced by template, in
+// order of first appearance
+func Variables(template string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(template); {
+		open := strings.IndexByte(template[i:], '{')
+		if open < 0 {
+			break
+		}
+
+		start := i + open + 1
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed expression at offset %d", i+open)
+		}
+
+		_, specs, err := parseExpression(template[start : start+end])
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range specs {
+			if !seen[spec.name] {
+				seen[spec.name] = true
+				names = append(names, spec.name)
+			}
+		}
+		i = start + end + 1
+	}
+
+	return names, nil
+}
+
+// parseExpression splits the body of an expression into operator and varspecs
+func parseExpression(expression string) (operator, []varSpec, error) {
+	if expression == "" {
+		return operator{}, nil, fmt.Errorf("empty expression")
+	}
+
+	op := simpleOperator
+	if o, ok := operators[expression[0]]; ok {
+		op = o
+		expression = expression[1:]
+	} else if strings.ContainsRune("=,!@|", rune(expression[0])) {
+		return operator{}, nil, fmt.Errorf("reserved operator %q", expression[0])
+	}
+
+	var specs []varSpec
+	for _, raw := range strings.Split(expression, ",") {
+		spec := varSpec{name: raw}
+
+		if strings.HasSuffix(raw, "*") {
+			spec.name = strings.TrimSuffix(raw, "*")
+			spec.explode = true
+		} else if colon := strings.IndexByte(raw, ':'); colon >= 0 {
+			spec.name = raw[:colon]
+			prefix, err := strconv.Atoi(raw[colon+1:])
+			if err != nil || prefix <= 0 || prefix >= 10000 {
+				return operator{}, nil, fmt.Errorf("invalid prefix in %q", raw)
+			}
+			spec.prefix = prefix
+		}
+
+		if !validName(spec.name) {
+			return operator{}, nil, fmt.Errorf("invalid variable name %q", spec.name)
+		}
+		specs = append(specs, spec)
+	}
+
+	return op, specs, nil
+}
+
+// expandExpression expands the body of a single {...} expression
+func expandExpression(expression string, vars map[string]interface{}) (string, error) {
+	op, specs, err := parseExpression(expression)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	first := true
+
+	for _, spec := range specs {
+		expanded, defined, err := expandVar(op, spec, vars[spec.name])
+		if err != nil {
+			return "", err
+		}
+		if !defined {
+			continue
+		}
+
+		if first {
+			result.WriteString(op.first)
+			first = false
+		} else {
+			result.WriteString(op.sep)
+		}
+		result.WriteString(expanded)
+	}
+
+	return result.String(), nil
+}
+
+// expandVar expands one variable. defined is false when the variable should
+// be skipped entirely.
+func expandVar(op operator, spec varSpec, value interface{}) (expanded string, defined bool, err error) {
+	switch v := value.(type) {
+	case nil:
+		return "", false, nil
+
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return expandList(op, spec, items)
+
+	case []interface{}:
+		return expandList(op, spec, v)
+
+	case map[string]string:
+		pairs := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			pairs[key] = item
+		}
+		return expandMap(op, spec, pairs)
+
+	case map[string]interface{}:
+		return expandMap(op, spec, v)
+
+	default:
+		s := fmt.Sprint(v)
+		if spec.prefix > 0 && utf8.RuneCountInString(s) > spec.prefix {
+			s = string([]rune(s)[:spec.prefix])
+		}
+
+		var result strings.Builder
+		if op.named {
+			result.WriteString(encode(spec.name, false))
+			if s == "" {
+				result.WriteString(op.ifEmpty)
+				return result.String(), true, nil
+			}
+			result.WriteString("=")
+		}
+		result.WriteString(encode(s, op.allowReserved))
+		return result.String(), true, nil
+	}
+}
+
+// expandList expands a list value
+func expandList(op operator, spec varSpec, items []interface{}) (string, bool, error) {
+	if len(items) == 0 {
+		return "", false, nil
+	}
+	if spec.prefix > 0 {
+		return "", false, fmt.Errorf("prefix modifier not allowed on list variable %q", spec.name)
+	}
+
+	encoded := make([]string, len(items))
+	for i, item := range items {
+		encoded[i] = encode(fmt.Sprint(item), op.allowReserved)
+	}
+
+	if !spec.explode {
+		joined := strings.Join(encoded, ",")
+		if op.named {
+			return encode(spec.name, false) + "=" + joined, true, nil
+		}
+		return joined, true, nil
+	}
+
+	if op.named {
+		for i, item := range encoded {
+			if item == "" {
+				encoded[i] = encode(spec.name, false) + op.ifEmpty
+			} else {
+				encoded[i] = encode(spec.name, false) + "=" + item
+			}
+		}
+	}
+	return strings.Join(encoded, op.sep), true, nil
+}
+
+// expandMap expands an associative array value
+func expandMap(op operator, spec varSpec, pairs map[string]interface{}) (string, bool, error) {
+	if len(pairs) == 0 {
+		return "", false, nil
+	}
+	if spec.prefix > 0 {
+		return "", false, fmt.Errorf("prefix modifier not allowed on map variable %q", spec.name)
+	}
+
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		k := encode(key, op.allowReserved)
+		v := encode(fmt.Sprint(pairs[key]), op.allowReserved)
+
+		if !spec.explode {
+			parts = append(parts, k, v)
+		} else if op.named && v == "" {
+			parts = append(parts, k+op.ifEmpty)
+		} else {
+			parts = append(parts, k+"="+v)
+		}
+	}
+
+	if !spec.explode {
+		joined := strings.Join(parts, ",")
+		if op.named {
+			return encode(spec.name, false) + "=" + joined, true, nil
+		}
+		return joined, true, nil
+	}
+	return strings.Join(parts, op.sep), true, nil
+}
+
+// encode percent-encodes s. Unreserved characters are always kept; reserved
+// characters and existing percent-encoded triplets are kept when
+// allowReserved is set.
+func encode(s string, allowReserved bool) string {
+	var result strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUnreserved(c):
+			result.WriteByte(c)
+		case allowReserved && isReserved(c):
+			result.WriteByte(c)
+		case allowReserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			result.WriteString(s[i : i+3])
+			i += 2
+		default:
+			fmt.Fprintf(&result, "%%%02X", c)
+		}
+	}
+
+	return result.String()
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isReserved(c byte) bool {
+	return strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// validName reports whether name is a valid RFC 6570 varname
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '.':
+		case c == '%' && i+2 < len(name) && isHex(name[i+1]) && isHex(name[i+2]):
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
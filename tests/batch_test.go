@@ -0,0 +1,386 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
+)
+
+// batchServer is a line-delimited JSON-RPC server on a TCP port that
+// negotiates a protocol version with batching and answers batch frames with
+// batch frames
+type batchServer struct {
+	listener net.Listener
+
+	mu     sync.Mutex
+	conn   net.Conn
+	frames []json.RawMessage
+}
+
+func newBatchServer(t *testing.T) *batchServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &batchServer{listener: listener}
+	go s.serve()
+	return s
+}
+
+func (s *batchServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// sentFrames returns every frame the client wrote
+func (s *batchServer) sentFrames() []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]json.RawMessage(nil), s.frames...)
+}
+
+func (s *batchServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		frame := append(json.RawMessage(nil), scanner.Bytes()...)
+		s.mu.Lock()
+		s.frames = append(s.frames, frame)
+		s.mu.Unlock()
+
+		messages, err := transport.DecodeFrame(frame)
+		if err != nil {
+			return
+		}
+		var responses []*mcp.Message
+		for _, message := range messages {
+			// Responses to the server's own requests need no answer
+			if message.ID != nil && message.Method != "" {
+				responses = append(responses, s.answer(message))
+			}
+		}
+		if len(responses) == 0 {
+			continue
+		}
+
+		var data []byte
+		if frame[0] == '[' {
+			data, _ = json.Marshal(responses)
+		} else {
+			data, _ = json.Marshal(responses[0])
+		}
+		if _, err := conn.Write(append(data, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+// write sends a raw frame to the client
+func (s *batchServer) write(t *testing.T, frame string) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.conn.Write([]byte(frame + "\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+}
+
+func (s *batchServer) answer(request *mcp.Message) *mcp.Message {
+	switch request.Method {
+	case "initialize":
+		return mcp.NewResponse(request.ID, mcp.InitializeResponse{
+			ProtocolVersion: mcp.ProtocolVersion20250326,
+			ServerInfo:      mcp.ServerInfo{Name: "batch-server", Version: "1.0.0"},
+		})
+	case "tools/list":
+		return mcp.NewResponse(request.ID, mcp.ListToolsResponse{Tools: []mcp.Tool{{Name: "echo"}}})
+	case "prompts/list":
+		return mcp.NewResponse(request.ID, mcp.ListPromptsResponse{})
+	}
+	return mcp.NewErrorResponse(request.ID, mcp.ErrorCodeMethodNotFound, "method not found", nil)
+}
+
+// newBatchClient connects and initializes a client with the batch server
+func newBatchClient(t *testing.T, server *batchServer, middleware ...client.Middleware) *client.Client {
+	t.Helper()
+
+	c := client.NewClient(transport.NewTCPTransport("127.0.0.1", server.port()), client.ClientConfig{
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Timeout:    5 * time.Second,
+		Middleware: middleware,
+	})
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect() })
+	if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if !c.SupportsFeature(mcp.FeatureBatching) {
+		t.Fatal("Expected batching on protocol 2025-03-26")
+	}
+	return c
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("Requests share one frame", func(t *testing.T) {
+		server := newBatchServer(t)
+		c := newBatchClient(t, server)
+		ctx := context.Background()
+
+		results, err := c.Batch(ctx,
+			client.BatchItem{Method: "tools/list"},
+			client.BatchItem{Method: "missing/method"},
+			client.BatchItem{Method: "prompts/list"},
+		)
+		if err != nil {
+			t.Fatalf("Batch failed: %v", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("Expected 3 results, got %d", len(results))
+		}
+
+		var tools mcp.ListToolsResponse
+		if err := results[0].Decode(&tools); err != nil || len(tools.Tools) != 1 || tools.Tools[0].Name != "echo" {
+			t.Errorf("Unexpected tools/list result: %+v, %v", tools, err)
+		}
+		if !client.IsErrorCode(results[1].Decode(nil), mcp.ErrorCodeMethodNotFound) {
+			t.Errorf("Expected method not found, got %v", results[1].Decode(nil))
+		}
+		if err := results[2].Decode(&mcp.ListPromptsResponse{}); err != nil {
+			t.Errorf("Unexpected prompts/list error: %v", err)
+		}
+
+		var batches int
+		for _, frame := range server.sentFrames() {
+			if frame[0] != '[' {
+				continue
+			}
+			batches++
+			messages, _ := transport.DecodeFrame(frame)
+			if len(messages) != 3 || messages[0].Method != "tools/list" || messages[2].Method != "prompts/list" {
+				t.Errorf("Unexpected batch frame: %s", frame)
+			}
+		}
+		if batches != 1 {
+			t.Errorf("Expected one batch frame, got %d", batches)
+		}
+	})
+
+	t.Run("Requests are sent one by one without batching", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.ListToolsResponse{}, nil
+		})
+		c := newTestClient(t, m)
+		if c.SupportsFeature(mcp.FeatureBatching) {
+			t.Fatal("Batching was removed in 2025-06-18")
+		}
+
+		results, err := c.Batch(context.Background(),
+			client.BatchItem{Method: "tools/list"},
+			client.BatchItem{Method: "tools/list"},
+		)
+		if err != nil {
+			t.Fatalf("Batch failed: %v", err)
+		}
+		for i, result := range results {
+			if err := result.Decode(nil); err != nil {
+				t.Errorf("Request %d failed: %v", i, err)
+			}
+		}
+	})
+
+	t.Run("Middleware may answer without sending", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.ListToolsResponse{}, nil
+		})
+		cached := func(next client.Handler) client.Handler {
+			return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+				if request.Method == "prompts/list" {
+					return mcp.NewResponse(request.ID, mcp.ListPromptsResponse{}), nil
+				}
+				return next(ctx, request)
+			}
+		}
+		c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+			config.Middleware = []client.Middleware{cached}
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		results, err := c.Batch(ctx,
+			client.BatchItem{Method: "prompts/list"},
+			client.BatchItem{Method: "tools/list"},
+		)
+		if err != nil {
+			t.Fatalf("Batch failed: %v", err)
+		}
+		for i, result := range results {
+			if err := result.Decode(nil); err != nil {
+				t.Errorf("Request %d failed: %v", i, err)
+			}
+		}
+	})
+}
+
+func TestBatchCancelled(t *testing.T) {
+	server := newBatchServer(t)
+	// prompts/list is held back until the batch gives up, so the frame is
+	// still open when tools/list is cancelled
+	stall := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			if request.Method == "prompts/list" {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return next(ctx, request)
+		}
+	}
+	c := newBatchClient(t, server, stall)
+
+	// The deadline passes well before the frame would be flushed without
+	// prompts/list
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	results, err := c.Batch(ctx,
+		client.BatchItem{Method: "tools/list"},
+		client.BatchItem{Method: "prompts/list"},
+	)
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if !errors.Is(results[0].Decode(nil), context.DeadlineExceeded) {
+		t.Errorf("Expected tools/list to time out, got %v", results[0].Decode(nil))
+	}
+
+	if !c.IsConnected() || !c.IsInitialized() {
+		t.Fatal("A cancelled batch must leave the session usable")
+	}
+	for _, frame := range server.sentFrames() {
+		messages, _ := transport.DecodeFrame(frame)
+		for _, message := range messages {
+			if message.Method == "tools/list" {
+				t.Errorf("Withdrawn request was sent: %s", frame)
+			}
+		}
+	}
+
+	tools, err := c.ListTools(context.Background())
+	if err != nil || len(tools) != 1 {
+		t.Errorf("ListTools after a cancelled batch: %v, %v", tools, err)
+	}
+}
+
+func TestBatchMiddlewareWaitsForSibling(t *testing.T) {
+	server := newBatchServer(t)
+	// prompts/list is only sent once tools/list was answered, which needs
+	// the frame to leave without it
+	listed := make(chan struct{})
+	ordered := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			switch request.Method {
+			case "tools/list":
+				defer close(listed)
+			case "prompts/list":
+				select {
+				case <-listed:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+	c := newBatchClient(t, server, ordered)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	results, err := c.Batch(ctx,
+		client.BatchItem{Method: "tools/list"},
+		client.BatchItem{Method: "prompts/list"},
+	)
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	for i, result := range results {
+		if err := result.Decode(nil); err != nil {
+			t.Errorf("Request %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestIncomingBatch(t *testing.T) {
+	server := newBatchServer(t)
+	c := newBatchClient(t, server)
+	c.OnRequest("roots/list", func(ctx context.Context, request *mcp.Message) (interface{}, error) {
+		return mcp.ListRootsResult{Roots: []mcp.Root{{URI: "file:///tmp"}}}, nil
+	})
+
+	server.write(t, `[{"jsonrpc":"2.0","id":"a","method":"ping"},`+
+		`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"},`+
+		`{"jsonrpc":"2.0","id":"b","method":"roots/list"}]`)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, frame := range server.sentFrames() {
+			messages, _ := transport.DecodeFrame(frame)
+			if len(messages) == 0 || messages[0].ID != "a" && messages[0].ID != "b" {
+				continue
+			}
+			if frame[0] != '[' || len(messages) != 2 {
+				t.Fatalf("Expected one batch response, got %s", frame)
+			}
+			for _, message := range messages {
+				if message.Method != "" || message.Error != nil || message.Result == nil {
+					t.Errorf("Unexpected response: %+v", message)
+				}
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("No response to the batch")
+}
+
+func TestDecodeFrame(t *testing.T) {
+	messages, err := transport.DecodeFrame([]byte(`{"jsonrpc":"2.0","method":"ping","id":1}`))
+	if err != nil || len(messages) != 1 || messages[0].Method != "ping" {
+		t.Errorf("Unexpected single message: %v, %v", messages, err)
+	}
+
+	messages, err = transport.DecodeFrame([]byte(` [{"jsonrpc":"2.0","id":1,"result":{}},{"jsonrpc":"2.0","method":"notifications/progress"}]`))
+	if err != nil || len(messages) != 2 || messages[1].Method != "notifications/progress" {
+		t.Errorf("Unexpected batch: %v, %v", messages, err)
+	}
+
+	if _, err := transport.DecodeFrame([]byte(`[]`)); err == nil {
+		t.Error("Expected an empty batch to fail")
+	}
+}
@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// toolServer serves a tool list that tests can change, counting tools/list
+type toolServer struct {
+	mu    sync.Mutex
+	tools []mcp.Tool
+	lists int32
+}
+
+func newToolServer(m *mockTransport, tools ...mcp.Tool) *toolServer {
+	s := &toolServer{tools: tools}
+	m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		atomic.AddInt32(&s.lists, 1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return mcp.ListToolsResponse{Tools: s.tools}, nil
+	})
+	return s
+}
+
+func (s *toolServer) setTools(tools ...mcp.Tool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tools = tools
+}
+
+func (s *toolServer) listCount() int32 {
+	return atomic.LoadInt32(&s.lists)
+}
+
+// listTools calls ListTools n times
+func listTools(t *testing.T, c *client.Client, n int) []mcp.Tool {
+	t.Helper()
+
+	var tools []mcp.Tool
+	for i := 0; i < n; i++ {
+		var err error
+		tools, err = c.ListTools(context.Background())
+		if err != nil {
+			t.Fatalf("ListTools failed: %v", err)
+		}
+	}
+	return tools
+}
+
+func TestCatalogCache(t *testing.T) {
+	enableCache := func(config *client.ClientConfig) { config.CacheCatalog = true }
+	listChanged := mcp.ServerCapabilities{Tools: &mcp.ToolsCapability{ListChanged: true}}
+
+	t.Run("Lists are cached until list_changed", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, listChanged)
+		server := newToolServer(m, mcp.Tool{Name: "echo"})
+		c := newConfiguredClient(t, m, enableCache)
+
+		listTools(t, c, 3)
+		if got := server.listCount(); got != 1 {
+			t.Fatalf("Expected 1 tools/list request, got %d", got)
+		}
+
+		server.setTools(mcp.Tool{Name: "echo"}, mcp.Tool{Name: "search"})
+		m.push(mcp.NewNotification(mcp.NotificationToolsListChanged, nil))
+
+		deadline := time.Now().Add(2 * time.Second)
+		var tools []mcp.Tool
+		for time.Now().Before(deadline) {
+			if tools = listTools(t, c, 1); len(tools) == 2 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if len(tools) != 2 {
+			t.Fatalf("Expected the new tool list, got %+v", tools)
+		}
+	})
+
+	t.Run("Not cached without the listChanged capability", func(t *testing.T) {
+		m := newMockTransport()
+		server := newToolServer(m, mcp.Tool{Name: "echo"})
+		c := newConfiguredClient(t, m, enableCache)
+
+		listTools(t, c, 2)
+		if got := server.listCount(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, listChanged)
+		server := newToolServer(m, mcp.Tool{Name: "echo"})
+		c := newTestClient(t, m)
+
+		listTools(t, c, 2)
+		if got := server.listCount(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+
+	t.Run("InvalidateCatalog forces a new request", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, listChanged)
+		server := newToolServer(m, mcp.Tool{Name: "echo"})
+		c := newConfiguredClient(t, m, enableCache)
+
+		listTools(t, c, 1)
+		c.InvalidateCatalog()
+		listTools(t, c, 1)
+		if got := server.listCount(); got != 2 {
+			t.Errorf("Expected 2 tools/list requests, got %d", got)
+		}
+	})
+}
+
+func TestOnCatalogChanged(t *testing.T) {
+	m := newMockTransport()
+	withServerCapabilities(m, mcp.ServerCapabilities{Tools: &mcp.ToolsCapability{ListChanged: true}})
+	server := newToolServer(m,
+		mcp.Tool{Name: "grep"},
+		mcp.Tool{Name: "fetch", Description: "Fetch a URL"},
+		mcp.Tool{Name: "echo"},
+	)
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) { config.CacheCatalog = true })
+
+	diffs := make(chan client.CatalogDiff, 10)
+	c.OnCatalogChanged(func(diff client.CatalogDiff) { diffs <- diff })
+
+	listTools(t, c, 1)
+
+	// A notification with no actual change is not reported
+	m.push(mcp.NewNotification(mcp.NotificationToolsListChanged, nil))
+
+	server.setTools(
+		mcp.Tool{Name: "fetch", Description: "Fetch a URL and follow redirects"},
+		mcp.Tool{Name: "echo"},
+		mcp.Tool{Name: "search"},
+	)
+	m.push(mcp.NewNotification(mcp.NotificationToolsListChanged, nil))
+
+	var diff client.CatalogDiff
+	select {
+	case diff = <-diffs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnCatalogChanged handler not called")
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Name != "search" {
+		t.Errorf("Unexpected added tools: %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "grep" {
+		t.Errorf("Unexpected removed tools: %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Description != "Fetch a URL and follow redirects" {
+		t.Errorf("Unexpected changed tools: %+v", diff.Changed)
+	}
+
+	select {
+	case extra := <-diffs:
+		t.Errorf("Unexpected extra diff: %+v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The refreshed list is cached again
+	before := server.listCount()
+	listTools(t, c, 1)
+	if got := server.listCount(); got != before {
+		t.Errorf("Expected the refreshed list to be cached, got %d new requests", got-before)
+	}
+}
+
+func TestCatalogRefreshKeepsReading(t *testing.T) {
+	m := newMockTransport()
+	withServerCapabilities(m, mcp.ServerCapabilities{Tools: &mcp.ToolsCapability{ListChanged: true}})
+	newToolServer(m, mcp.Tool{Name: "echo"})
+	c := newTestClient(t, m)
+	listTools(t, c, 1)
+
+	diffs := make(chan client.CatalogDiff, 1)
+	c.OnCatalogChanged(func(diff client.CatalogDiff) { diffs <- diff })
+
+	const notifications = 300
+	var received int32
+	c.OnNotification("notifications/custom", func(*mcp.Message) { atomic.AddInt32(&received, 1) })
+
+	// Hold the refresh until far more notifications than the client buffers
+	// were pushed behind it
+	release := make(chan struct{})
+	m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		<-release
+		return mcp.ListToolsResponse{Tools: []mcp.Tool{{Name: "echo"}, {Name: "search"}}}, nil
+	})
+	m.push(mcp.NewNotification(mcp.NotificationToolsListChanged, nil))
+
+	pushed := make(chan struct{})
+	go func() {
+		defer close(pushed)
+		for i := 0; i < notifications; i++ {
+			m.push(mcp.NewNotification("notifications/custom", nil))
+		}
+	}()
+
+	select {
+	case <-pushed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Client stopped reading while the tool list was refreshed")
+	}
+	close(release)
+
+	select {
+	case diff := <-diffs:
+		if len(diff.Added) != 1 || diff.Added[0].Name != "search" {
+			t.Errorf("Unexpected diff: %+v", diff)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnCatalogChanged handler not called")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&received) < notifications && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&received); got != notifications {
+		t.Errorf("Expected %d notifications, got %d", notifications, got)
+	}
+}
+
+func TestIdempotentToolRetriedAfterReconnect(t *testing.T) {
+	m := newMockTransport()
+	idempotent := true
+	newToolServer(m, mcp.Tool{Name: "lookup", Annotations: &mcp.ToolAnnotations{IdempotentHint: &idempotent}})
+
+	var calls int32
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			m.Close()
+		}
+		return mcp.CallToolResponse{Content: []mcp.Content{mcp.NewTextContent("found")}}, nil
+	})
+	c := newReconnectingClient(t, m, 3)
+
+	listTools(t, c, 1)
+
+	result, err := c.CallTool(context.Background(), "lookup", nil)
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if text, ok := result.Content[0].AsText(); !ok || text.Text != "found" {
+		t.Errorf("Unexpected result: %+v", result.Content)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Expected tools/call to be sent twice, got %d", got)
+	}
+}
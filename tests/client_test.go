@@ -1,9 +1,19 @@
 package tests
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
 	"github.com/kunalkushwaha/mcp-navigator-go/pkg/transport"
 )
 
@@ -66,3 +76,327 @@ func TestClientConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestConcurrentRequests(t *testing.T) {
+	m := newMockTransport()
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.CallToolRequest
+		json.Unmarshal(params, &req)
+
+		// Answer out of order so responses have to be routed by ID
+		delay, _ := req.Arguments["delay"].(float64)
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+
+		return mcp.CallToolResponse{
+			Content: []mcp.Content{{Type: "text", Text: req.Name}},
+		}, nil
+	})
+	c := newTestClient(t, m)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("tool-%d", i)
+			result, err := c.CallTool(context.Background(), name, map[string]interface{}{
+				"delay": (20 - i) * 5,
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if got := result.Content[0].Text; got != name {
+				errs <- fmt.Errorf("expected response for %s, got %s", name, got)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDisconnectFailsPendingRequests(t *testing.T) {
+	m := newMockTransport()
+	block := make(chan struct{})
+	defer close(block)
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		<-block
+		return mcp.CallToolResponse{}, nil
+	})
+	c := newTestClient(t, m)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.CallTool(context.Background(), "slow", nil)
+		done <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	c.Disconnect()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected error for request pending during disconnect")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Pending request was not woken by Disconnect")
+	}
+}
+
+func TestRequestCancellation(t *testing.T) {
+	m := newMockTransport()
+	block := make(chan struct{})
+	defer close(block)
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		<-block
+		return mcp.CallToolResponse{}, nil
+	})
+	c := newTestClient(t, m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := c.CallTool(ctx, "slow", nil); err == nil {
+		t.Fatal("Expected error from cancelled call")
+	}
+
+	call := m.waitForSent(t, func(message *mcp.Message) bool {
+		return message.Method == "tools/call"
+	})
+	cancelled := m.waitForSent(t, func(message *mcp.Message) bool {
+		return message.Method == mcp.NotificationCancelled
+	})
+
+	params, _ := cancelled.Params.(map[string]interface{})
+	if params["requestId"] != call.ID {
+		t.Errorf("Expected cancellation for request %v, got %v", call.ID, params["requestId"])
+	}
+	if params["reason"] == "" {
+		t.Error("Expected a cancellation reason")
+	}
+}
+
+func TestPagination(t *testing.T) {
+	pagedTools := func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.ListToolsRequest
+		json.Unmarshal(params, &req)
+
+		pages := map[string]mcp.ListToolsResponse{
+			"":       {Tools: []mcp.Tool{{Name: "a"}, {Name: "b"}}, NextCursor: "page-2"},
+			"page-2": {Tools: []mcp.Tool{{Name: "c"}}, NextCursor: "page-3"},
+			"page-3": {Tools: []mcp.Tool{{Name: "d"}}},
+		}
+		return pages[req.Cursor], nil
+	}
+
+	t.Run("ListTools follows cursors", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/list", pagedTools)
+		c := newTestClient(t, m)
+
+		tools, err := c.ListTools(context.Background())
+		if err != nil {
+			t.Fatalf("ListTools failed: %v", err)
+		}
+		if len(tools) != 4 || tools[3].Name != "d" {
+			t.Errorf("Expected 4 tools across pages, got %+v", tools)
+		}
+
+		page, err := c.ListToolsPage(context.Background(), "page-2")
+		if err != nil {
+			t.Fatalf("ListToolsPage failed: %v", err)
+		}
+		if len(page.Tools) != 1 || page.NextCursor != "page-3" {
+			t.Errorf("Unexpected page: %+v", page)
+		}
+	})
+
+	t.Run("Page limit stops endless cursors", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("prompts/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.ListPromptsResponse{Prompts: []mcp.Prompt{{Name: "p"}}, NextCursor: "again"}, nil
+		})
+		c := client.NewClientBuilder().
+			WithTransport(m).
+			WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))).
+			WithMaxPages(3).
+			Build()
+
+		ctx := context.Background()
+		c.Connect(ctx)
+		defer c.Disconnect()
+		if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+
+		if _, err := c.ListPrompts(ctx); !errors.Is(err, client.ErrPageLimitExceeded) {
+			t.Errorf("Expected ErrPageLimitExceeded, got %v", err)
+		}
+	})
+}
+
+func TestResourceTemplates(t *testing.T) {
+	m := newMockTransport()
+	m.handle("resources/templates/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		return mcp.ListResourceTemplatesResponse{
+			ResourceTemplates: []mcp.ResourceTemplate{{URITemplate: "file:///{+path}", Name: "file"}},
+		}, nil
+	})
+	m.handle("resources/read", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.ReadResourceRequest
+		json.Unmarshal(params, &req)
+		return mcp.ReadResourceResponse{Contents: []mcp.ResourceContents{{URI: req.URI, Text: "hello"}}}, nil
+	})
+	c := newTestClient(t, m)
+	ctx := context.Background()
+
+	templates, err := c.ListResourceTemplates(ctx)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].URITemplate != "file:///{+path}" {
+		t.Fatalf("Unexpected templates: %+v", templates)
+	}
+
+	result, err := c.ReadResourceTemplate(ctx, templates[0].URITemplate, map[string]interface{}{
+		"path": "src/main.go",
+	})
+	if err != nil {
+		t.Fatalf("ReadResourceTemplate failed: %v", err)
+	}
+	if result.Contents[0].URI != "file:///src/main.go" {
+		t.Errorf("Expected expanded URI, got %s", result.Contents[0].URI)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	m := newMockTransport()
+	m.handle("completion/complete", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.CompleteRequest
+		json.Unmarshal(params, &req)
+		if req.Ref.Type != "ref/prompt" || req.Ref.Name != "review" || req.Argument.Name != "branch" {
+			return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidParams, Message: "unexpected request"}
+		}
+		var values []string
+		for _, branch := range []string{"main", "master", "develop"} {
+			if strings.HasPrefix(branch, req.Argument.Value) {
+				values = append(values, branch)
+			}
+		}
+		return mcp.CompleteResponse{Completion: mcp.Completion{Values: values, Total: len(values)}}, nil
+	})
+	c := newTestClient(t, m)
+
+	completion, err := c.Complete(context.Background(), mcp.NewPromptReference("review"), "branch", "ma")
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if len(completion.Values) != 2 || completion.Values[0] != "main" || completion.Values[1] != "master" {
+		t.Errorf("Unexpected completion values: %v", completion.Values)
+	}
+
+	if _, err := c.Complete(context.Background(), mcp.NewResourceReference("file:///{path}"), "path", ""); err == nil {
+		t.Error("Expected error for rejected completion request")
+	}
+}
+
+func TestProtocolVersionNegotiation(t *testing.T) {
+	// withProtocolVersion makes the mock server answer initialize with version
+	withProtocolVersion := func(m *mockTransport, version string) chan string {
+		offered := make(chan string, 1)
+		m.handle("initialize", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var req mcp.InitializeRequest
+			json.Unmarshal(params, &req)
+			offered <- req.ProtocolVersion
+			return mcp.InitializeResponse{
+				ProtocolVersion: version,
+				ServerInfo:      mcp.ServerInfo{Name: "mock-server", Version: "1.0.0"},
+			}, nil
+		})
+		return offered
+	}
+
+	t.Run("Offers newest version", func(t *testing.T) {
+		m := newMockTransport()
+		offered := withProtocolVersion(m, mcp.ProtocolVersion20250618)
+		c := newTestClient(t, m)
+
+		if version := <-offered; version != mcp.Version {
+			t.Errorf("Expected client to offer %s, got %s", mcp.Version, version)
+		}
+		if c.ProtocolVersion() != mcp.ProtocolVersion20250618 {
+			t.Errorf("Expected negotiated version %s, got %s", mcp.ProtocolVersion20250618, c.ProtocolVersion())
+		}
+		if !c.SupportsFeature(mcp.FeatureStructuredContent) {
+			t.Error("Expected structured content to be supported")
+		}
+	})
+
+	t.Run("Accepts downgrade", func(t *testing.T) {
+		m := newMockTransport()
+		withProtocolVersion(m, mcp.ProtocolVersion20241105)
+		c := newTestClient(t, m)
+
+		if c.ProtocolVersion() != mcp.ProtocolVersion20241105 {
+			t.Errorf("Expected negotiated version %s, got %s", mcp.ProtocolVersion20241105, c.ProtocolVersion())
+		}
+		if c.SupportsFeature(mcp.FeatureToolAnnotations) || c.SupportsFeature(mcp.FeatureResourceLinks) {
+			t.Error("Expected newer features to be disabled on 2024-11-05")
+		}
+	})
+
+	t.Run("Rejects unsupported version", func(t *testing.T) {
+		m := newMockTransport()
+		withProtocolVersion(m, "2023-01-01")
+		c := client.NewClient(m, client.ClientConfig{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Timeout: 5 * time.Second})
+
+		ctx := context.Background()
+		c.Connect(ctx)
+		defer c.Disconnect()
+
+		err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"})
+		var versionErr *client.UnsupportedProtocolVersionError
+		if !errors.As(err, &versionErr) {
+			t.Fatalf("Expected UnsupportedProtocolVersionError, got %v", err)
+		}
+		if versionErr.Received != "2023-01-01" || versionErr.Requested != mcp.Version {
+			t.Errorf("Unexpected error details: %+v", versionErr)
+		}
+		if c.IsInitialized() {
+			t.Error("Client should not be initialized after a version mismatch")
+		}
+	})
+}
+
+func TestSupportsFeature(t *testing.T) {
+	tests := []struct {
+		version string
+		feature mcp.Feature
+		want    bool
+	}{
+		{mcp.ProtocolVersion20241105, mcp.FeatureAudioContent, false},
+		{mcp.ProtocolVersion20250326, mcp.FeatureAudioContent, true},
+		{mcp.ProtocolVersion20250326, mcp.FeatureElicitation, false},
+		{mcp.ProtocolVersion20250618, mcp.FeatureElicitation, true},
+		{"2099-01-01", mcp.FeatureCompletions, false},
+		{mcp.ProtocolVersion20250618, mcp.Feature("unknown"), false},
+		{mcp.ProtocolVersion20241105, mcp.FeatureBatching, false},
+		{mcp.ProtocolVersion20250326, mcp.FeatureBatching, true},
+		{mcp.ProtocolVersion20250618, mcp.FeatureBatching, false},
+	}
+
+	for _, tt := range tests {
+		if got := mcp.SupportsFeature(tt.version, tt.feature); got != tt.want {
+			t.Errorf("SupportsFeature(%s, %s) = %v, want %v", tt.version, tt.feature, got, tt.want)
+		}
+	}
+}
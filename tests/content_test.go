@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+func TestContentUnmarshal(t *testing.T) {
+	data := `{"content": [
+		{"type": "text", "text": "hello"},
+		{"type": "image", "data": "aGVsbG8=", "mimeType": "image/png"},
+		{"type": "audio", "data": "AAEC", "mimeType": "audio/wav"},
+		{"type": "resource", "resource": {"uri": "file:///a.txt", "mimeType": "text/plain", "text": "inline"}},
+		{"type": "resource", "resource": {"uri": "file:///a.bin", "blob": "AQID"}},
+		{"type": "resource_link", "uri": "file:///big.log", "name": "big.log", "size": 2048},
+		{"type": "chart", "series": [1, 2, 3]}
+	]}`
+
+	var result mcp.CallToolResponse
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(result.Content) != 7 {
+		t.Fatalf("Expected 7 content items, got %d", len(result.Content))
+	}
+
+	if text, ok := result.Content[0].AsText(); !ok || text.Text != "hello" {
+		t.Errorf("Unexpected text content: %+v", result.Content[0])
+	}
+	if _, ok := result.Content[0].AsImage(); ok {
+		t.Error("Text content should not convert to an image")
+	}
+
+	image, ok := result.Content[1].AsImage()
+	if !ok || image.MimeType != "image/png" {
+		t.Errorf("Unexpected image content: %+v", result.Content[1])
+	}
+	if decoded, err := result.Content[1].DecodeBlob(); err != nil || string(decoded) != "hello" {
+		t.Errorf("DecodeBlob = %q, %v", decoded, err)
+	}
+
+	if audio, ok := result.Content[2].AsAudio(); !ok || audio.MimeType != "audio/wav" {
+		t.Errorf("Unexpected audio content: %+v", result.Content[2])
+	}
+
+	embedded, ok := result.Content[3].AsEmbeddedResource()
+	if !ok || embedded.Resource.Text != "inline" || embedded.Resource.IsBlob() {
+		t.Errorf("Unexpected embedded text resource: %+v", result.Content[3])
+	}
+
+	blob, err := result.Content[4].DecodeBlob()
+	if err != nil || len(blob) != 3 || blob[2] != 3 {
+		t.Errorf("Embedded blob DecodeBlob = %v, %v", blob, err)
+	}
+
+	link, ok := result.Content[5].AsResourceLink()
+	if !ok || link.URI != "file:///big.log" || link.Name != "big.log" || link.Size != 2048 {
+		t.Errorf("Unexpected resource link: %+v", result.Content[5])
+	}
+
+	custom := result.Content[6]
+	if custom.Type != "chart" {
+		t.Errorf("Unexpected custom content: %+v", custom)
+	}
+	if _, err := custom.DecodeBlob(); err == nil {
+		t.Error("Expected DecodeBlob to fail for custom content")
+	}
+}
+
+func TestContentMarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		content mcp.Content
+		want    string
+	}{
+		{"text", mcp.NewTextContent("hi"), `{"type":"text","text":"hi"}`},
+		{"empty text", mcp.NewTextContent(""), `{"type":"text","text":""}`},
+		{"image", mcp.NewImageContent([]byte("hello"), "image/png"), `{"type":"image","data":"aGVsbG8=","mimeType":"image/png"}`},
+		{"audio", mcp.NewAudioContent([]byte{0, 1, 2}, "audio/wav"), `{"type":"audio","data":"AAEC","mimeType":"audio/wav"}`},
+		{
+			"embedded resource",
+			mcp.NewEmbeddedResource(mcp.ResourceContents{URI: "file:///a.bin", Blob: "AQID"}),
+			`{"type":"resource","resource":{"uri":"file:///a.bin","blob":"AQID"}}`,
+		},
+		{
+			"embedded empty text resource",
+			mcp.NewEmbeddedResource(mcp.ResourceContents{URI: "file:///empty.txt"}),
+			`{"type":"resource","resource":{"uri":"file:///empty.txt","text":""}}`,
+		},
+		{"resource link", mcp.NewResourceLink("file:///a.txt", "a.txt"), `{"type":"resource_link","uri":"file:///a.txt","name":"a.txt"}`},
+		{
+			"custom content keeps known fields",
+			mcp.Content{Type: "chart", Name: "sales"},
+			`{"type":"chart","name":"sales"}`,
+		},
+		{
+			"stray fields are dropped",
+			mcp.Content{Type: "text", Text: "hi", URI: "file:///ignored", Data: "ignored"},
+			`{"type":"text","text":"hi"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.content)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+		})
+	}
+
+	t.Run("resource content requires resource", func(t *testing.T) {
+		var content mcp.Content
+		if err := json.Unmarshal([]byte(`{"type":"resource"}`), &content); err == nil {
+			t.Error("Expected error for resource content without resource")
+		}
+	})
+}
+
+func TestReadResourceBlob(t *testing.T) {
+	var result mcp.ReadResourceResponse
+	data := `{"contents":[{"uri":"file:///logo.png","mimeType":"image/png","blob":"iVBORw=="}]}`
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	contents := result.Contents[0]
+	if !contents.IsBlob() {
+		t.Fatal("Expected blob resource")
+	}
+	decoded, err := contents.DecodeBlob()
+	if err != nil || len(decoded) != 4 || decoded[1] != 'P' {
+		t.Errorf("DecodeBlob = %v, %v", decoded, err)
+	}
+}
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+func TestTypedErrors(t *testing.T) {
+	t.Run("Server error keeps code and data", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return nil, &mcp.ErrorInfo{
+				Code:    mcp.ErrorCodeInvalidParams,
+				Message: "missing argument: query",
+				Data:    map[string]interface{}{"argument": "query"},
+			}
+		})
+		c := newTestClient(t, m)
+
+		_, err := c.CallTool(context.Background(), "search", nil)
+		var mcpErr *client.MCPError
+		if !errors.As(err, &mcpErr) {
+			t.Fatalf("Expected *MCPError, got %v", err)
+		}
+		if mcpErr.Code != mcp.ErrorCodeInvalidParams || mcpErr.Message != "missing argument: query" {
+			t.Errorf("Unexpected error: %+v", mcpErr)
+		}
+		if data, _ := mcpErr.Data.(map[string]interface{}); data["argument"] != "query" {
+			t.Errorf("Expected error data to be preserved, got %v", mcpErr.Data)
+		}
+		if !client.IsErrorCode(err, mcp.ErrorCodeInvalidParams) {
+			t.Error("IsErrorCode should match wrapped MCPError")
+		}
+		if client.IsErrorCode(err, mcp.ErrorCodeMethodNotFound) {
+			t.Error("IsErrorCode matched the wrong code")
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		m := newMockTransport()
+		block := make(chan struct{})
+		defer close(block)
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			<-block
+			return mcp.CallToolResponse{}, nil
+		})
+
+		c := client.NewClient(m, client.ClientConfig{
+			Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+			Timeout: 50 * time.Millisecond,
+		})
+		ctx := context.Background()
+		c.Connect(ctx)
+		defer c.Disconnect()
+		if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+
+		_, err := c.CallTool(ctx, "slow", nil)
+		if !errors.Is(err, client.ErrTimeout) {
+			t.Errorf("Expected ErrTimeout, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("Connection closed", func(t *testing.T) {
+		m := newMockTransport()
+		block := make(chan struct{})
+		defer close(block)
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			<-block
+			return mcp.CallToolResponse{}, nil
+		})
+		c := newTestClient(t, m)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := c.CallTool(context.Background(), "slow", nil)
+			done <- err
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		m.Close()
+
+		var err error
+		select {
+		case err = <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Pending request was not failed")
+		}
+
+		if !errors.Is(err, client.ErrConnectionClosed) {
+			t.Errorf("Expected ErrConnectionClosed, got %v", err)
+		}
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("Expected the transport cause to be wrapped, got %v", err)
+		}
+		var transportErr *client.TransportError
+		if !errors.As(err, &transportErr) {
+			t.Errorf("Expected *TransportError, got %v", err)
+		}
+	})
+
+	t.Run("Write to a closed connection", func(t *testing.T) {
+		m := &brokenPipeTransport{mockTransport: newMockTransport()}
+		c := client.NewClient(m, client.ClientConfig{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+		ctx := context.Background()
+		c.Connect(ctx)
+		defer c.Disconnect()
+		if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+
+		m.broken.Store(true)
+		_, err := c.ListTools(ctx)
+		if !errors.Is(err, client.ErrConnectionClosed) {
+			t.Errorf("Expected ErrConnectionClosed, got %v", err)
+		}
+		if !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("Expected the transport cause to be wrapped, got %v", err)
+		}
+		if c.IsConnected() {
+			t.Error("Expected the client to be disconnected")
+		}
+	})
+
+	t.Run("Not initialized", func(t *testing.T) {
+		c := client.NewClient(newMockTransport(), client.ClientConfig{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+
+		if _, err := c.ListTools(context.Background()); !errors.Is(err, client.ErrNotInitialized) {
+			t.Errorf("Expected ErrNotInitialized, got %v", err)
+		}
+		if err := c.Initialize(context.Background(), mcp.ClientInfo{}); !errors.Is(err, client.ErrNotConnected) {
+			t.Errorf("Expected ErrNotConnected, got %v", err)
+		}
+	})
+
+	t.Run("Invalid response", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return map[string]interface{}{"tools": "not-a-list"}, nil
+		})
+		c := newTestClient(t, m)
+
+		if _, err := c.ListTools(context.Background()); !errors.Is(err, client.ErrInvalidResponse) {
+			t.Errorf("Expected ErrInvalidResponse, got %v", err)
+		}
+	})
+}
+
+// brokenPipeTransport fails every write once broken is set, while still
+// reporting itself as connected
+type brokenPipeTransport struct {
+	*mockTransport
+	broken atomic.Bool
+}
+
+func (b *brokenPipeTransport) Send(message *mcp.Message) error {
+	if b.broken.Load() {
+		return fmt.Errorf("failed to write message: %w", io.ErrClosedPipe)
+	}
+	return b.mockTransport.Send(message)
+}
@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/jsonschema"
+)
+
+// mustSchema decodes a schema written as JSON
+func mustSchema(t *testing.T, source string) map[string]interface{} {
+	t.Helper()
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(source), &schema); err != nil {
+		t.Fatalf("Invalid test schema: %v", err)
+	}
+	return schema
+}
+
+func TestJSONSchemaValidate(t *testing.T) {
+	schema := mustSchema(t, `{
+		"type": "object",
+		"properties": {
+			"query":    {"type": "string", "minLength": 1, "maxLength": 10},
+			"limit":    {"type": "integer", "minimum": 1, "maximum": 100},
+			"ratio":    {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.5},
+			"mode":     {"enum": ["fast", "exact"]},
+			"tags":     {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+			"location": {
+				"type": "object",
+				"properties": {
+					"lat": {"type": "number"},
+					"lng": {"type": "number"}
+				},
+				"required": ["lat", "lng"],
+				"additionalProperties": false
+			},
+			"since":    {"type": "string", "format": "date-time"},
+			"email":    {"type": "string", "format": "email"},
+			"id":       {"type": "string", "pattern": "^[a-z]+-[0-9]+$"},
+			"nullable": {"type": ["string", "null"]},
+			"address":  {"$ref": "#/$defs/address"}
+		},
+		"required": ["query"],
+		"$defs": {
+			"address": {"type": "object", "required": ["city"]}
+		}
+	}`)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []jsonschema.Violation
+	}{
+		{
+			name:  "Valid",
+			value: map[string]interface{}{"query": "go", "limit": 10, "mode": "fast", "nullable": nil},
+		},
+		{
+			name:     "Missing required",
+			value:    map[string]interface{}{},
+			expected: []jsonschema.Violation{{Path: "query", Message: "is required"}},
+		},
+		{
+			name:     "Wrong type",
+			value:    map[string]interface{}{"query": 42},
+			expected: []jsonschema.Violation{{Path: "query", Message: "expected string, got integer"}},
+		},
+		{
+			name:  "Integer bounds and fractions",
+			value: map[string]interface{}{"query": "go", "limit": 1.5, "ratio": 0.75},
+			expected: []jsonschema.Violation{
+				{Path: "limit", Message: "expected integer, got number"},
+				{Path: "ratio", Message: "must be a multiple of 0.5"},
+			},
+		},
+		{
+			name:  "Bounds",
+			value: map[string]interface{}{"query": "", "limit": 0, "ratio": 0},
+			expected: []jsonschema.Violation{
+				{Path: "limit", Message: "must be at least 1"},
+				{Path: "query", Message: "must be at least 1 characters long"},
+				{Path: "ratio", Message: "must be greater than 0"},
+			},
+		},
+		{
+			name:     "Enum",
+			value:    map[string]interface{}{"query": "go", "mode": "slow"},
+			expected: []jsonschema.Violation{{Path: "mode", Message: `must be one of "fast", "exact"`}},
+		},
+		{
+			name:  "Arrays",
+			value: map[string]interface{}{"query": "go", "tags": []interface{}{"a", 1, "a"}},
+			expected: []jsonschema.Violation{
+				{Path: "tags", Message: "must have at most 2 items"},
+				{Path: "tags", Message: "items 0 and 2 are equal, items must be unique"},
+				{Path: "tags[1]", Message: "expected string, got integer"},
+			},
+		},
+		{
+			name: "Nested objects",
+			value: map[string]interface{}{
+				"query":    "go",
+				"location": map[string]interface{}{"lat": "north", "alt": 3},
+			},
+			expected: []jsonschema.Violation{
+				{Path: "location.lng", Message: "is required"},
+				{Path: "location.alt", Message: "is not a known property"},
+				{Path: "location.lat", Message: "expected number, got string"},
+			},
+		},
+		{
+			name: "Formats and patterns",
+			value: map[string]interface{}{
+				"query": "go",
+				"since": "yesterday",
+				"email": "not an email",
+				"id":    "ABC",
+			},
+			expected: []jsonschema.Violation{
+				{Path: "email", Message: "must be a valid email"},
+				{Path: "id", Message: `must match pattern "^[a-z]+-[0-9]+$"`},
+				{Path: "since", Message: "must be a valid date-time"},
+			},
+		},
+		{
+			name:     "Union types",
+			value:    map[string]interface{}{"query": "go", "nullable": 1},
+			expected: []jsonschema.Violation{{Path: "nullable", Message: "expected string or null, got integer"}},
+		},
+		{
+			name:     "References",
+			value:    map[string]interface{}{"query": "go", "address": map[string]interface{}{}},
+			expected: []jsonschema.Violation{{Path: "address.city", Message: "is required"}},
+		},
+		{
+			name:     "Go values",
+			value:    map[string]interface{}{"query": "go", "tags": []string{"x"}, "limit": int64(5)},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := jsonschema.Validate(schema, tt.value)
+			if !reflect.DeepEqual(violations, tt.expected) {
+				t.Errorf("Validate() = %v, want %v", violations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaCombinators(t *testing.T) {
+	schema := mustSchema(t, `{
+		"oneOf": [
+			{"type": "string"},
+			{"type": "integer"},
+			{"type": "number"}
+		],
+		"not": {"const": 13}
+	}`)
+
+	if v := jsonschema.Validate(schema, "text"); v != nil {
+		t.Errorf("Expected string to be valid, got %v", v)
+	}
+	if v := jsonschema.Validate(schema, 1.5); v != nil {
+		t.Errorf("Expected number to be valid, got %v", v)
+	}
+
+	// Integers match both integer and number
+	v := jsonschema.Validate(schema, 7)
+	if len(v) != 1 || v[0].Message != "matches 2 schemas, expected exactly one" {
+		t.Errorf("Unexpected violations for 7: %v", v)
+	}
+
+	v = jsonschema.Validate(schema, 13)
+	if len(v) != 2 || v[1].Message != "matches a schema it must not match" {
+		t.Errorf("Unexpected violations for 13: %v", v)
+	}
+
+	v = jsonschema.Validate(schema, true)
+	if len(v) != 1 || v[0].Message != "does not match any of the allowed schemas" {
+		t.Errorf("Unexpected violations for true: %v", v)
+	}
+
+	if v := jsonschema.Validate(nil, map[string]interface{}{"anything": 1}); v != nil {
+		t.Errorf("Expected nil schema to accept anything, got %v", v)
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+func TestServerLogging(t *testing.T) {
+	t.Run("SetLogLevel sends logging/setLevel", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{Logging: &mcp.LoggingCapability{}})
+		levels := make(chan mcp.LoggingLevel, 2)
+		m.handle("logging/setLevel", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var req mcp.SetLevelRequest
+			json.Unmarshal(params, &req)
+			levels <- req.Level
+			return struct{}{}, nil
+		})
+		c := newTestClient(t, m)
+		ctx := context.Background()
+
+		if err := c.SetLogLevel(ctx, mcp.LoggingLevelWarning); err != nil {
+			t.Fatalf("SetLogLevel failed: %v", err)
+		}
+		if level := <-levels; level != mcp.LoggingLevelWarning {
+			t.Errorf("Expected level warning, got %s", level)
+		}
+		if c.LogLevel() != mcp.LoggingLevelWarning {
+			t.Errorf("Expected LogLevel warning, got %s", c.LogLevel())
+		}
+
+		// The level is sent again when a new session is initialized
+		c.Disconnect()
+		if err := c.Connect(ctx); err != nil {
+			t.Fatalf("Reconnect failed: %v", err)
+		}
+		if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+		select {
+		case level := <-levels:
+			if level != mcp.LoggingLevelWarning {
+				t.Errorf("Expected restored level warning, got %s", level)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Log level was not restored")
+		}
+	})
+
+	t.Run("SetLogLevel rejects unknown levels", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{Logging: &mcp.LoggingCapability{}})
+		c := newTestClient(t, m)
+
+		if err := c.SetLogLevel(context.Background(), "verbose"); err == nil {
+			t.Error("Expected error for unknown level")
+		}
+	})
+
+	t.Run("SetLogLevel requires logging capability", func(t *testing.T) {
+		m := newMockTransport()
+		c := newTestClient(t, m)
+
+		err := c.SetLogLevel(context.Background(), mcp.LoggingLevelDebug)
+		if !errors.Is(err, client.ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("Log messages are delivered to the sink", func(t *testing.T) {
+		m := newMockTransport()
+		c := newTestClient(t, m)
+
+		received := make(chan mcp.LoggingMessageNotification, 1)
+		c.SetLogSink(client.LogSinkFunc(func(message mcp.LoggingMessageNotification) {
+			received <- message
+		}))
+
+		m.push(mcp.NewNotification(mcp.NotificationMessage, mcp.LoggingMessageNotification{
+			Level:  mcp.LoggingLevelError,
+			Logger: "db",
+			Data:   map[string]interface{}{"error": "connection refused"},
+		}))
+
+		select {
+		case message := <-received:
+			if message.Level != mcp.LoggingLevelError || message.Logger != "db" {
+				t.Errorf("Unexpected log message: %+v", message)
+			}
+			if got := client.FormatLogMessage(message); got != `[error] db: {"error":"connection refused"}` {
+				t.Errorf("Unexpected formatted message: %s", got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Log sink was not called")
+		}
+	})
+
+	t.Run("Writer sink", func(t *testing.T) {
+		var buf bytes.Buffer
+		sink := client.NewWriterLogSink(&buf)
+		sink.Log(mcp.LoggingMessageNotification{Level: mcp.LoggingLevelInfo, Data: "started"})
+
+		if strings.TrimSpace(buf.String()) != "[info] started" {
+			t.Errorf("Unexpected sink output: %q", buf.String())
+		}
+	})
+}
+
+func TestLoggingLevels(t *testing.T) {
+	if mcp.LoggingLevelDebug.Severity() >= mcp.LoggingLevelEmergency.Severity() {
+		t.Error("Expected debug to be less severe than emergency")
+	}
+	if mcp.LoggingLevel("verbose").Severity() != -1 {
+		t.Error("Expected unknown level to have severity -1")
+	}
+
+	level, err := mcp.ParseLoggingLevel("notice")
+	if err != nil || level != mcp.LoggingLevelNotice {
+		t.Errorf("ParseLoggingLevel(notice) = %s, %v", level, err)
+	}
+	if _, err := mcp.ParseLoggingLevel("trace"); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// newMetricsServer returns a mock whose tools/call succeeds, times out for
+// the "slow" tool and fails for any other tool
+func newMetricsServer() *mockTransport {
+	m := newMockTransport()
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var request mcp.CallToolRequest
+		json.Unmarshal(params, &request)
+		switch request.Name {
+		case "echo":
+			return mcp.CallToolResponse{Content: []mcp.Content{{Type: "text", Text: "ok"}}}, nil
+		case "slow":
+			time.Sleep(time.Second)
+			return mcp.CallToolResponse{}, nil
+		}
+		return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidTool, Message: "unknown tool"}
+	})
+	return m
+}
+
+func TestMetrics(t *testing.T) {
+	m := newMetricsServer()
+	metrics := client.NewMetrics()
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.Metrics = metrics
+		config.Timeout = 100 * time.Millisecond
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := c.CallTool(ctx, "echo", nil); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+	}
+	if _, err := c.CallTool(ctx, "missing", nil); err == nil {
+		t.Fatal("Expected unknown tool to fail")
+	}
+	if _, err := c.CallTool(ctx, "slow", nil); err == nil {
+		t.Fatal("Expected slow tool to time out")
+	}
+
+	snapshot := metrics.Snapshot()
+	if snapshot.Connections != 1 || snapshot.InFlight != 0 {
+		t.Errorf("Expected 1 connection and nothing in flight, got %d and %d", snapshot.Connections, snapshot.InFlight)
+	}
+	if len(snapshot.Methods) != 2 || snapshot.Methods[0].Method != "initialize" || snapshot.Methods[1].Method != "tools/call" {
+		t.Fatalf("Unexpected methods: %+v", snapshot.Methods)
+	}
+
+	calls := snapshot.Methods[1]
+	expected := map[string]int64{client.OutcomeOK: 2, client.OutcomeError: 1, client.OutcomeTimeout: 1}
+	for outcome, count := range expected {
+		if calls.Outcomes[outcome] != count {
+			t.Errorf("Expected %d %s outcomes, got %d", count, outcome, calls.Outcomes[outcome])
+		}
+	}
+	if calls.Requests != 4 || calls.Errors() != 2 {
+		t.Errorf("Expected 4 requests with 2 errors, got %d and %d", calls.Requests, calls.Errors())
+	}
+
+	latency := calls.Latency
+	if latency.Count != 4 || latency.Max < 100*time.Millisecond || latency.Mean() <= 0 {
+		t.Errorf("Unexpected latency: %+v", latency)
+	}
+	last := latency.Buckets[len(latency.Buckets)-1]
+	if last.UpperBound != 10*time.Second || last.Count != 4 {
+		t.Errorf("Expected every request in the last bucket, got %+v", last)
+	}
+	if latency.Buckets[0].Count > latency.Buckets[len(latency.Buckets)-1].Count {
+		t.Errorf("Expected cumulative buckets, got %+v", latency.Buckets)
+	}
+
+	c.Disconnect()
+	if connections := metrics.Snapshot().Connections; connections != 0 {
+		t.Errorf("Expected no connections after Disconnect, got %d", connections)
+	}
+}
+
+func TestMetricsPrometheus(t *testing.T) {
+	m := newMetricsServer()
+	metrics := client.NewMetrics()
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.Metrics = metrics
+	})
+
+	if _, err := c.CallTool(context.Background(), "echo", nil); err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", contentType)
+	}
+
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE mcp_client_requests_total counter",
+		`mcp_client_requests_total{method="tools/call",outcome="ok"} 1`,
+		"# TYPE mcp_client_request_duration_seconds histogram",
+		`mcp_client_request_duration_seconds_bucket{method="tools/call",le="10"} 1`,
+		`mcp_client_request_duration_seconds_bucket{method="tools/call",le="+Inf"} 1`,
+		`mcp_client_request_duration_seconds_count{method="tools/call"} 1`,
+		"mcp_client_requests_in_flight 0",
+		"mcp_client_connections 1",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, body)
+		}
+	}
+}
+
+// failingResponseWriter is a ResponseWriter whose client went away
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingResponseWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+func (failingResponseWriter) WriteString(string) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+func TestMetricsHandlerWriteError(t *testing.T) {
+	capture := &logCapture{}
+	previous := slog.Default()
+	slog.SetDefault(capture.logger())
+	defer slog.SetDefault(previous)
+
+	w := failingResponseWriter{httptest.NewRecorder()}
+	client.NewMetrics().Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	record := capture.find(t, "Failed to write metrics")
+	if record["error"] != "connection reset by peer" {
+		t.Errorf("Unexpected record: %v", record)
+	}
+}
@@ -0,0 +1,231 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// recorder collects middleware events from concurrent requests
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// tracing returns a middleware recording when each request enters and leaves it
+func (r *recorder) tracing(name string) client.Middleware {
+	return func(next client.Handler) client.Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			r.add(name + " " + client.RequestDirection(ctx).String() + " " + request.Method)
+			response, err := next(ctx, request)
+			r.add(name + " done " + request.Method)
+			return response, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	m := newMockTransport()
+	m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		return mcp.ListToolsResponse{}, nil
+	})
+
+	events := &recorder{}
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.Middleware = []client.Middleware{events.tracing("a"), events.tracing("b")}
+	})
+
+	if _, err := c.ListTools(context.Background()); err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+
+	expected := []string{
+		"a outgoing initialize", "b outgoing initialize", "b done initialize", "a done initialize",
+		"a outgoing tools/list", "b outgoing tools/list", "b done tools/list", "a done tools/list",
+	}
+	if got := events.list(); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected middleware order:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestMiddlewareSetsMeta(t *testing.T) {
+	m := newMockTransport()
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		return mcp.CallToolResponse{Content: []mcp.Content{{Type: "text", Text: "ok"}}}, nil
+	})
+
+	auth := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			if err := request.SetMeta("authorization", "Bearer secret"); err != nil {
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.Middleware = []client.Middleware{auth}
+	})
+
+	if _, err := c.CallToolWithProgress(context.Background(), "echo", map[string]interface{}{"text": "hi"}, func(mcp.ProgressNotification) {}); err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+
+	sent := m.waitForSent(t, func(message *mcp.Message) bool { return message.Method == "tools/call" })
+	params := sent.Params.(map[string]interface{})
+	meta := params["_meta"].(map[string]interface{})
+	if meta["authorization"] != "Bearer secret" {
+		t.Errorf("Expected authorization in _meta, got %v", meta)
+	}
+	if meta["progressToken"] == nil {
+		t.Errorf("Expected existing progress token to be kept, got %v", meta)
+	}
+	if params["name"] != "echo" {
+		t.Errorf("Expected params to be kept, got %v", params)
+	}
+}
+
+func TestSetMetaKeepsParams(t *testing.T) {
+	const large = int64(1)<<60 + 1
+	message := mcp.NewRequest(1, "tools/call", mcp.CallToolRequest{
+		Name:      "store",
+		Arguments: map[string]interface{}{"id": large},
+	})
+	if err := message.SetMeta("traceparent", "00-trace-span-01"); err != nil {
+		t.Fatalf("SetMeta failed: %v", err)
+	}
+
+	data, err := json.Marshal(message.Params)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var params struct {
+		Arguments map[string]json.Number `json:"arguments"`
+		Meta      map[string]string      `json:"_meta"`
+	}
+	if err := decoder.Decode(&params); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if got, _ := params.Arguments["id"].Int64(); got != large {
+		t.Errorf("Expected id %d, got %s", large, params.Arguments["id"])
+	}
+	if params.Meta["traceparent"] != "00-trace-span-01" {
+		t.Errorf("Unexpected _meta: %v", params.Meta)
+	}
+}
+
+func TestMiddlewareIncomingRequests(t *testing.T) {
+	m := newMockTransport()
+
+	events := &recorder{}
+	deny := func(next client.Handler) client.Handler {
+		return func(ctx context.Context, request *mcp.Message) (*mcp.Message, error) {
+			if client.RequestDirection(ctx) == client.Incoming && request.Method == "sampling/createMessage" {
+				return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidRequest, Message: "sampling disabled"}
+			}
+			return next(ctx, request)
+		}
+	}
+	c := client.NewClient(m, client.ClientConfig{
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Timeout:    5 * time.Second,
+		Middleware: []client.Middleware{events.tracing("audit"), deny},
+	})
+	c.SetRoots([]mcp.Root{{URI: "file:///work", Name: "work"}})
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer c.Disconnect()
+	if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	m.push(mcp.NewRequest("roots-1", "roots/list", nil))
+	response := m.waitForSent(t, responseTo("roots-1"))
+	if response.Error != nil {
+		t.Fatalf("Unexpected error response: %v", response.Error)
+	}
+
+	m.push(mcp.NewRequest("sample-1", "sampling/createMessage", map[string]interface{}{}))
+	response = m.waitForSent(t, responseTo("sample-1"))
+	if response.Error == nil || response.Error.Message != "sampling disabled" {
+		t.Errorf("Expected request to be rejected by middleware, got %+v", response)
+	}
+
+	got := strings.Join(events.list(), "\n")
+	for _, expected := range []string{"audit incoming roots/list", "audit done roots/list", "audit incoming sampling/createMessage"} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("Expected event %q, got:\n%s", expected, got)
+		}
+	}
+}
+
+func TestBuiltinMiddleware(t *testing.T) {
+	m := newMockTransport()
+
+	capture := &logCapture{}
+	var mu sync.Mutex
+	var timings []error
+	timing := client.TimingMiddleware(func(ctx context.Context, request *mcp.Message, duration time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if duration < 0 {
+			t.Errorf("Negative duration for %s", request.Method)
+		}
+		timings = append(timings, err)
+	})
+
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.Middleware = []client.Middleware{client.LoggingMiddleware(capture.logger()), timing}
+	})
+
+	// The mock does not answer tools/list, so this fails with a JSON-RPC error
+	if _, err := c.ListTools(context.Background()); err == nil {
+		t.Fatal("Expected ListTools to fail")
+	}
+
+	completed := capture.find(t, "Request completed")
+	if completed["method"] != "initialize" || completed["request_id"] != float64(1) ||
+		completed["direction"] != "outgoing" || completed["duration"] == nil {
+		t.Errorf("Unexpected record for initialize: %v", completed)
+	}
+	failed := capture.find(t, "Request failed")
+	if failed["level"] != "WARN" || failed["method"] != "tools/list" ||
+		!strings.Contains(failed["error"].(string), "method not found") {
+		t.Errorf("Unexpected record for tools/list: %v", failed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(timings) != 2 || timings[0] != nil {
+		t.Fatalf("Expected two timings with a successful initialize, got %v", timings)
+	}
+	var errInfo *mcp.ErrorInfo
+	if !errors.As(timings[1], &errInfo) || errInfo.Code != mcp.ErrorCodeMethodNotFound {
+		t.Errorf("Expected tools/list timing to carry the JSON-RPC error, got %v", timings[1])
+	}
+}
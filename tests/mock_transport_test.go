@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// mockHandler answers a single request sent by the client. Returning a non-nil
+// ErrorInfo produces a JSON-RPC error response.
+type mockHandler func(params json.RawMessage) (interface{}, *mcp.ErrorInfo)
+
+// mockTransport is an in-memory transport that plays the server side of an MCP
+// session. Messages are round-tripped through JSON so IDs and results look
+// exactly like they would coming off the wire.
+type mockTransport struct {
+	mu        sync.Mutex
+	connected bool
+	incoming  chan *mcp.Message
+	closed    chan struct{}
+	handlers  map[string]mockHandler
+	sent      []*mcp.Message
+
+	// connectErr makes Connect fail, simulating a server that is down
+	connectErr error
+}
+
+func newMockTransport() *mockTransport {
+	m := &mockTransport{
+		handlers: make(map[string]mockHandler),
+	}
+	m.handle("initialize", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		return mcp.InitializeResponse{
+			ProtocolVersion: mcp.Version,
+			ServerInfo:      mcp.ServerInfo{Name: "mock-server", Version: "1.0.0"},
+		}, nil
+	})
+	return m
+}
+
+// handle registers the server's answer for a method
+func (m *mockTransport) handle(method string, handler mockHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[method] = handler
+}
+
+// push delivers a server-initiated message to the client. Messages pushed
+// after the connection closed are lost, like they would be on the wire.
+func (m *mockTransport) push(message *mcp.Message) {
+	m.mu.Lock()
+	incoming, closed := m.incoming, m.closed
+	m.mu.Unlock()
+
+	select {
+	case <-closed:
+		return
+	default:
+	}
+
+	select {
+	case incoming <- roundTrip(message):
+	case <-closed:
+	}
+}
+
+// setConnectError makes every following Connect fail with err, or succeed
+// again if err is nil
+func (m *mockTransport) setConnectError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connectErr = err
+}
+
+// sentMessages returns a copy of everything the client has sent
+func (m *mockTransport) sentMessages() []*mcp.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*mcp.Message(nil), m.sent...)
+}
+
+// waitForSent waits until the client sends a message matching match
+func (m *mockTransport) waitForSent(t *testing.T, match func(*mcp.Message) bool) *mcp.Message {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, message := range m.sentMessages() {
+			if match(message) {
+				return message
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("Timed out waiting for client message")
+	return nil
+}
+
+// responseTo matches the client's response to the server request with id
+func responseTo(id string) func(*mcp.Message) bool {
+	return func(message *mcp.Message) bool {
+		return message.Method == "" && message.ID == id
+	}
+}
+
+func (m *mockTransport) Connect(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.connected {
+		return nil
+	}
+	if m.connectErr != nil {
+		return m.connectErr
+	}
+	m.incoming = make(chan *mcp.Message, 100)
+	m.closed = make(chan struct{})
+	m.connected = true
+	return nil
+}
+
+func (m *mockTransport) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.connected {
+		return nil
+	}
+	close(m.closed)
+	m.connected = false
+	return nil
+}
+
+func (m *mockTransport) Send(message *mcp.Message) error {
+	m.mu.Lock()
+	if !m.connected {
+		m.mu.Unlock()
+		return fmt.Errorf("transport not connected")
+	}
+	message = roundTrip(message)
+	m.sent = append(m.sent, message)
+	handler := m.handlers[message.Method]
+	m.mu.Unlock()
+
+	// Only requests get an answer
+	if message.Method == "" || message.ID == nil {
+		return nil
+	}
+
+	go func() {
+		var response *mcp.Message
+		if handler == nil {
+			response = mcp.NewErrorResponse(message.ID, mcp.ErrorCodeMethodNotFound, "method not found", nil)
+		} else {
+			params, _ := json.Marshal(message.Params)
+			result, errInfo := handler(params)
+			if errInfo != nil {
+				response = mcp.NewErrorResponse(message.ID, errInfo.Code, errInfo.Message, errInfo.Data)
+			} else {
+				response = mcp.NewResponse(message.ID, result)
+			}
+		}
+		m.push(response)
+	}()
+
+	return nil
+}
+
+func (m *mockTransport) Receive() (*mcp.Message, error) {
+	m.mu.Lock()
+	incoming, closed := m.incoming, m.closed
+	m.mu.Unlock()
+
+	if incoming == nil {
+		return nil, fmt.Errorf("transport not connected")
+	}
+
+	select {
+	case message := <-incoming:
+		return message, nil
+	case <-closed:
+		return nil, io.EOF
+	}
+}
+
+func (m *mockTransport) GetReader() io.Reader { return nil }
+func (m *mockTransport) GetWriter() io.Writer { return nil }
+
+func (m *mockTransport) IsConnected() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.connected
+}
+
+// roundTrip encodes and decodes a message the way a real transport would
+func roundTrip(message *mcp.Message) *mcp.Message {
+	data, err := json.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+	var decoded mcp.Message
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		panic(err)
+	}
+	return &decoded
+}
+
+// newTestClient returns a connected and initialized client backed by m
+func newTestClient(t *testing.T, m *mockTransport) *client.Client {
+	t.Helper()
+	return newConfiguredClient(t, m, func(*client.ClientConfig) {})
+}
+
+// newConfiguredClient is newTestClient with a chance to change the config
+func newConfiguredClient(t *testing.T, m *mockTransport, configure func(*client.ClientConfig)) *client.Client {
+	t.Helper()
+
+	config := client.ClientConfig{
+		Name:    "test-client",
+		Version: "1.0.0",
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Timeout: 5 * time.Second,
+	}
+	configure(&config)
+
+	c := client.NewClient(m, config)
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { c.Disconnect() })
+
+	if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	return c
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+func TestNotificationHandlers(t *testing.T) {
+	t.Run("Typed log message handler", func(t *testing.T) {
+		m := newMockTransport()
+		c := newTestClient(t, m)
+
+		received := make(chan mcp.LoggingMessageNotification, 1)
+		c.OnLogMessage(func(n mcp.LoggingMessageNotification) {
+			received <- n
+		})
+
+		m.push(mcp.NewNotification(mcp.NotificationMessage, mcp.LoggingMessageNotification{
+			Level:  "warning",
+			Logger: "db",
+			Data:   "disk almost full",
+		}))
+
+		select {
+		case n := <-received:
+			if n.Level != "warning" || n.Logger != "db" || n.Data != "disk almost full" {
+				t.Errorf("Unexpected log message: %+v", n)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Log message handler was not called")
+		}
+	})
+
+	t.Run("Handler can call back into the client", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.ListToolsResponse{Tools: []mcp.Tool{{Name: "echo"}}}, nil
+		})
+		c := newTestClient(t, m)
+
+		tools := make(chan []mcp.Tool, 1)
+		c.OnToolsListChanged(func() {
+			list, err := c.ListTools(context.Background())
+			if err != nil {
+				t.Errorf("ListTools from handler failed: %v", err)
+			}
+			tools <- list
+		})
+
+		m.push(mcp.NewNotification(mcp.NotificationToolsListChanged, nil))
+
+		select {
+		case list := <-tools:
+			if len(list) != 1 || list[0].Name != "echo" {
+				t.Errorf("Unexpected tools: %+v", list)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("list_changed handler did not complete")
+		}
+	})
+}
+
+func TestCallToolWithProgress(t *testing.T) {
+	m := newMockTransport()
+	received := make(chan mcp.ProgressNotification, 10)
+
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.CallToolRequest
+		json.Unmarshal(params, &req)
+		if req.Meta == nil || req.Meta.ProgressToken == nil {
+			return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidParams, Message: "missing progress token"}
+		}
+
+		// Progress for another request must not reach the callback
+		m.push(mcp.NewNotification(mcp.NotificationProgress, mcp.ProgressNotification{
+			ProgressToken: "other", Progress: 99,
+		}))
+		for i := 1; i <= 2; i++ {
+			m.push(mcp.NewNotification(mcp.NotificationProgress, mcp.ProgressNotification{
+				ProgressToken: req.Meta.ProgressToken, Progress: float64(i), Total: 2, Message: "working",
+			}))
+			<-received
+		}
+		return mcp.CallToolResponse{Content: []mcp.Content{{Type: "text", Text: "done"}}}, nil
+	})
+	c := newTestClient(t, m)
+
+	var updates []mcp.ProgressNotification
+	result, err := c.CallToolWithProgress(context.Background(), "slow", nil, func(p mcp.ProgressNotification) {
+		updates = append(updates, p)
+		received <- p
+	})
+	if err != nil {
+		t.Fatalf("CallToolWithProgress failed: %v", err)
+	}
+
+	if result.Content[0].Text != "done" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(updates) != 2 || updates[1].Progress != 2 || updates[1].Total != 2 || updates[1].Message != "working" {
+		t.Errorf("Unexpected progress updates: %+v", updates)
+	}
+}
@@ -0,0 +1,330 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// newReconnectingClient returns an initialized client backed by m that
+// reconnects with short backoffs
+func newReconnectingClient(t *testing.T, m *mockTransport, maxAttempts int) *client.Client {
+	t.Helper()
+	return newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.Reconnect = &client.ReconnectPolicy{
+			MaxAttempts:    maxAttempts,
+			InitialBackoff: 5 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+		}
+	})
+}
+
+// countInitialize counts initialize requests and records the client name
+func countInitialize(m *mockTransport, count *int32, names chan<- string) {
+	m.handle("initialize", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.InitializeRequest
+		json.Unmarshal(params, &req)
+		atomic.AddInt32(count, 1)
+		names <- req.ClientInfo.Name
+		return mcp.InitializeResponse{
+			ProtocolVersion: mcp.Version,
+			Capabilities: mcp.ServerCapabilities{
+				Resources: &mcp.ResourcesCapability{Subscribe: true},
+				Logging:   &mcp.LoggingCapability{},
+			},
+			ServerInfo: mcp.ServerInfo{Name: "mock-server", Version: "1.0.0"},
+		}, nil
+	})
+}
+
+func TestReconnect(t *testing.T) {
+	t.Run("Session is restored after the connection drops", func(t *testing.T) {
+		m := newMockTransport()
+		var initializes int32
+		names := make(chan string, 10)
+		countInitialize(m, &initializes, names)
+
+		subscribes := make(chan string, 10)
+		m.handle("resources/subscribe", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var req mcp.SubscribeRequest
+			json.Unmarshal(params, &req)
+			subscribes <- req.URI
+			return struct{}{}, nil
+		})
+		levels := make(chan mcp.LoggingLevel, 10)
+		m.handle("logging/setLevel", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var req mcp.SetLevelRequest
+			json.Unmarshal(params, &req)
+			levels <- req.Level
+			return struct{}{}, nil
+		})
+
+		c := newReconnectingClient(t, m, 3)
+		<-names
+
+		reconnected := make(chan struct{}, 1)
+		c.OnReconnect(func() { reconnected <- struct{}{} })
+
+		ctx := context.Background()
+		if err := c.SubscribeResource(ctx, "file:///a", func(mcp.ResourceUpdatedNotification) {}); err != nil {
+			t.Fatalf("SubscribeResource failed: %v", err)
+		}
+		<-subscribes
+		if err := c.SetLogLevel(ctx, mcp.LoggingLevelWarning); err != nil {
+			t.Fatalf("SetLogLevel failed: %v", err)
+		}
+		<-levels
+
+		// The server goes away; the client notices without sending anything
+		m.Close()
+
+		select {
+		case <-reconnected:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Client did not reconnect")
+		}
+
+		if name := <-names; name != "test-client" {
+			t.Errorf("Expected original client info, got %q", name)
+		}
+		if got := atomic.LoadInt32(&initializes); got != 2 {
+			t.Errorf("Expected 2 initialize requests, got %d", got)
+		}
+		select {
+		case uri := <-subscribes:
+			if uri != "file:///a" {
+				t.Errorf("Unexpected subscription to %s", uri)
+			}
+		case <-time.After(2 * time.Second):
+			t.Error("Subscription not restored")
+		}
+		select {
+		case level := <-levels:
+			if level != mcp.LoggingLevelWarning {
+				t.Errorf("Expected warning level, got %s", level)
+			}
+		case <-time.After(2 * time.Second):
+			t.Error("Log level not restored")
+		}
+		if !c.IsInitialized() {
+			t.Error("Expected client to be initialized again")
+		}
+	})
+
+	t.Run("Idempotent request is retried", func(t *testing.T) {
+		m := newMockTransport()
+		var calls int32
+		m.handle("tools/list", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				// Drop the connection before answering
+				m.Close()
+			}
+			return mcp.ListToolsResponse{Tools: []mcp.Tool{{Name: "echo"}}}, nil
+		})
+		c := newReconnectingClient(t, m, 3)
+
+		tools, err := c.ListTools(context.Background())
+		if err != nil {
+			t.Fatalf("ListTools failed: %v", err)
+		}
+		if len(tools) != 1 || tools[0].Name != "echo" {
+			t.Errorf("Unexpected tools: %+v", tools)
+		}
+		if got := atomic.LoadInt32(&calls); got != 2 {
+			t.Errorf("Expected tools/list to be sent twice, got %d", got)
+		}
+	})
+
+	t.Run("Tool calls are not retried", func(t *testing.T) {
+		m := newMockTransport()
+		var calls int32
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				m.Close()
+			}
+			return mcp.CallToolResponse{Content: []mcp.Content{mcp.NewTextContent("done")}}, nil
+		})
+		c := newReconnectingClient(t, m, 3)
+
+		ctx := context.Background()
+		_, err := c.CallTool(ctx, "deploy", nil)
+		if !errors.Is(err, client.ErrConnectionClosed) {
+			t.Fatalf("Expected ErrConnectionClosed, got %v", err)
+		}
+		if got := atomic.LoadInt32(&calls); got != 1 {
+			t.Errorf("Expected a single tools/call, got %d", got)
+		}
+
+		// The next call waits for the restored session
+		if _, err := c.CallTool(ctx, "deploy", nil); err != nil {
+			t.Fatalf("CallTool after reconnect failed: %v", err)
+		}
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		m := newMockTransport()
+		c := newReconnectingClient(t, m, 2)
+
+		m.setConnectError(fmt.Errorf("connection refused"))
+		m.Close()
+
+		_, err := c.ListTools(context.Background())
+		if !errors.Is(err, client.ErrReconnectFailed) {
+			t.Fatalf("Expected ErrReconnectFailed, got %v", err)
+		}
+
+		// Once the server is back the next request reconnects again
+		m.setConnectError(nil)
+		if _, err := c.ListPrompts(context.Background()); err != nil && !client.IsErrorCode(err, mcp.ErrorCodeMethodNotFound) {
+			t.Fatalf("Expected a restored session, got %v", err)
+		}
+		if !c.IsInitialized() {
+			t.Error("Expected client to be initialized again")
+		}
+	})
+
+	t.Run("Disconnect stops reconnecting", func(t *testing.T) {
+		m := newMockTransport()
+		var initializes int32
+		names := make(chan string, 10)
+		countInitialize(m, &initializes, names)
+		c := newReconnectingClient(t, m, 3)
+
+		c.Disconnect()
+		time.Sleep(50 * time.Millisecond)
+
+		_, err := c.ListTools(context.Background())
+		if !errors.Is(err, client.ErrNotInitialized) {
+			t.Errorf("Expected ErrNotInitialized, got %v", err)
+		}
+		if got := atomic.LoadInt32(&initializes); got != 1 {
+			t.Errorf("Expected no new initialize after Disconnect, got %d", got)
+		}
+	})
+
+	t.Run("Disabled without a policy", func(t *testing.T) {
+		m := newMockTransport()
+		c := newTestClient(t, m)
+
+		m.Close()
+		deadline := time.Now().Add(2 * time.Second)
+		for c.IsInitialized() && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		_, err := c.ListTools(context.Background())
+		if !errors.Is(err, client.ErrNotInitialized) {
+			t.Errorf("Expected ErrNotInitialized, got %v", err)
+		}
+	})
+}
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	policy := client.ReconnectPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Jitter:         0.1,
+	}
+
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tt := range tests {
+		delay := policy.Backoff(tt.attempt)
+		low := time.Duration(float64(tt.base) * 0.9)
+		high := time.Duration(float64(tt.base) * 1.1)
+		if delay < low || delay > high {
+			t.Errorf("Backoff(%d) = %v, want between %v and %v", tt.attempt, delay, low, high)
+		}
+	}
+}
+
+func TestReconnectPolicyWithoutJitter(t *testing.T) {
+	policy := client.ReconnectPolicy{InitialBackoff: 100 * time.Millisecond}
+	for i := 0; i < 10; i++ {
+		if delay := policy.Backoff(1); delay != 100*time.Millisecond {
+			t.Fatalf("Backoff(1) = %v, want exactly 100ms without jitter", delay)
+		}
+	}
+
+	var defaults client.ReconnectPolicy
+	low, high := 400*time.Millisecond, 600*time.Millisecond
+	if delay := defaults.Backoff(1); delay < low || delay > high {
+		t.Errorf("Backoff(1) of the zero policy = %v, want between %v and %v", delay, low, high)
+	}
+}
+
+// hangingDialTransport is a mockTransport whose Connect hangs until its
+// context ends once hang is set
+type hangingDialTransport struct {
+	*mockTransport
+	hang    atomic.Bool
+	dialing chan struct{}
+	once    sync.Once
+}
+
+func (h *hangingDialTransport) Connect(ctx context.Context) error {
+	if !h.hang.Load() {
+		return h.mockTransport.Connect(ctx)
+	}
+	h.once.Do(func() { close(h.dialing) })
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestDisconnectStopsHangingReconnect(t *testing.T) {
+	h := &hangingDialTransport{mockTransport: newMockTransport(), dialing: make(chan struct{})}
+	c := client.NewClient(h, client.ClientConfig{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Reconnect: &client.ReconnectPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 5 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+		},
+	})
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	h.hang.Store(true)
+	h.Close()
+	select {
+	case <-h.dialing:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Client did not try to reconnect")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.Disconnect()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Disconnect did not stop the hanging dial")
+	}
+}
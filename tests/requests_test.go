@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+func TestServerRequests(t *testing.T) {
+	t.Run("Ping is answered automatically", func(t *testing.T) {
+		m := newMockTransport()
+		newTestClient(t, m)
+
+		m.push(mcp.NewRequest("ping-1", "ping", nil))
+
+		response := m.waitForSent(t, responseTo("ping-1"))
+		if response.Error != nil {
+			t.Errorf("Expected ping result, got error %v", response.Error)
+		}
+		if response.Result == nil {
+			t.Error("Expected empty result object for ping")
+		}
+	})
+
+	t.Run("Unknown method returns method not found", func(t *testing.T) {
+		m := newMockTransport()
+		newTestClient(t, m)
+
+		m.push(mcp.NewRequest("req-1", "unknown/method", nil))
+
+		response := m.waitForSent(t, responseTo("req-1"))
+		if response.Error == nil || response.Error.Code != mcp.ErrorCodeMethodNotFound {
+			t.Errorf("Expected method not found error, got %+v", response)
+		}
+	})
+
+	t.Run("Registered handler result and error", func(t *testing.T) {
+		m := newMockTransport()
+		c := newTestClient(t, m)
+
+		c.OnRequest("custom/echo", func(ctx context.Context, request *mcp.Message) (interface{}, error) {
+			if request.Params == nil {
+				return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidParams, Message: "params required"}
+			}
+			return request.Params, nil
+		})
+
+		m.push(mcp.NewRequest("req-ok", "custom/echo", map[string]interface{}{"value": "hi"}))
+		m.push(mcp.NewRequest("req-err", "custom/echo", nil))
+
+		ok := m.waitForSent(t, responseTo("req-ok"))
+		if result, _ := ok.Result.(map[string]interface{}); result["value"] != "hi" {
+			t.Errorf("Unexpected echo result: %+v", ok.Result)
+		}
+
+		failed := m.waitForSent(t, responseTo("req-err"))
+		if failed.Error == nil || failed.Error.Code != mcp.ErrorCodeInvalidParams {
+			t.Errorf("Expected invalid params error, got %+v", failed)
+		}
+	})
+}
+
+func TestRoots(t *testing.T) {
+	m := newMockTransport()
+	c := client.NewClient(m, client.ClientConfig{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Timeout: 5 * time.Second,
+	})
+	c.SetRoots([]mcp.Root{{URI: "file:///home/user/project", Name: "project"}})
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer c.Disconnect()
+	if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	if roots := initializeCapabilities(t, m).Roots; roots == nil || !roots.ListChanged {
+		t.Errorf("Expected roots capability with listChanged, got %+v", roots)
+	}
+
+	m.push(mcp.NewRequest("roots-1", "roots/list", nil))
+	response := m.waitForSent(t, responseTo("roots-1"))
+
+	data, _ := json.Marshal(response.Result)
+	var result mcp.ListRootsResult
+	json.Unmarshal(data, &result)
+	if len(result.Roots) != 1 || result.Roots[0].URI != "file:///home/user/project" {
+		t.Errorf("Unexpected roots: %+v", result.Roots)
+	}
+
+	if err := c.SetRoots(nil); err != nil {
+		t.Fatalf("SetRoots failed: %v", err)
+	}
+	m.waitForSent(t, func(message *mcp.Message) bool {
+		return message.Method == mcp.NotificationRootsListChanged
+	})
+}
+
+func TestRootsSetAfterInitialize(t *testing.T) {
+	m := newMockTransport()
+	c := newTestClient(t, m)
+
+	if err := c.SetRoots([]mcp.Root{{URI: "file:///late"}}); err == nil {
+		t.Error("Expected SetRoots to fail when roots were not advertised")
+	}
+	if roots := c.GetRoots(); len(roots) != 0 {
+		t.Errorf("Expected no roots, got %+v", roots)
+	}
+	for _, message := range m.sentMessages() {
+		if message.Method == mcp.NotificationRootsListChanged {
+			t.Error("roots/list_changed sent without the roots capability")
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// initializeCapabilities returns the capabilities the client sent in initialize
+func initializeCapabilities(t *testing.T, m *mockTransport) mcp.ClientCapabilities {
+	t.Helper()
+
+	request := m.waitForSent(t, func(message *mcp.Message) bool {
+		return message.Method == "initialize"
+	})
+
+	data, _ := json.Marshal(request.Params)
+	var params mcp.InitializeRequest
+	if err := json.Unmarshal(data, &params); err != nil {
+		t.Fatalf("Invalid initialize params: %v", err)
+	}
+	return params.Capabilities
+}
+
+func TestSampling(t *testing.T) {
+	t.Run("Capability not advertised without handler", func(t *testing.T) {
+		m := newMockTransport()
+		newTestClient(t, m)
+
+		if initializeCapabilities(t, m).Sampling != nil {
+			t.Error("Sampling capability advertised without a handler")
+		}
+	})
+
+	t.Run("OpenAI-compatible handler answers createMessage", func(t *testing.T) {
+		var chatRequest map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/chat/completions" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewDecoder(r.Body).Decode(&chatRequest)
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"model":"stub-model","choices":[{"message":{"role":"assistant","content":"Paris"},"finish_reason":"stop"}]}`)
+		}))
+		defer server.Close()
+
+		m := newMockTransport()
+		c := client.NewClientBuilder().
+			WithTransport(m).
+			WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))).
+			WithTimeout(5 * time.Second).
+			WithSamplingHandler(client.NewOpenAISamplingHandler(server.URL+"/v1", "", "local")).
+			Build()
+
+		ctx := context.Background()
+		if err := c.Connect(ctx); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer c.Disconnect()
+		if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+
+		if initializeCapabilities(t, m).Sampling == nil {
+			t.Error("Sampling capability not advertised with a handler")
+		}
+
+		m.push(mcp.NewRequest("sample-1", "sampling/createMessage", mcp.CreateMessageRequest{
+			Messages: []mcp.SamplingMessage{
+				{Role: "user", Content: mcp.Content{Type: "text", Text: "Capital of France?"}},
+			},
+			SystemPrompt: "Answer briefly",
+			MaxTokens:    16,
+		}))
+
+		response := m.waitForSent(t, responseTo("sample-1"))
+		if response.Error != nil {
+			t.Fatalf("Sampling failed: %v", response.Error)
+		}
+
+		data, _ := json.Marshal(response.Result)
+		var result mcp.CreateMessageResult
+		json.Unmarshal(data, &result)
+
+		if result.Content.Text != "Paris" || result.Model != "stub-model" || result.StopReason != "endTurn" {
+			t.Errorf("Unexpected sampling result: %+v", result)
+		}
+		if chatRequest["model"] != "local" || len(chatRequest["messages"].([]interface{})) != 2 {
+			t.Errorf("Unexpected chat request: %+v", chatRequest)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// logCapture collects JSON log records written by concurrent goroutines
+type logCapture struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (l *logCapture) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buffer.Write(p)
+}
+
+// logger returns a logger writing every level to the capture
+func (l *logCapture) logger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(l, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// records decodes the captured records
+func (l *logCapture) records(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(l.buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid log record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// find returns the first record with the given message
+func (l *logCapture) find(t *testing.T, message string) map[string]interface{} {
+	t.Helper()
+	for _, record := range l.records(t) {
+		if record["msg"] == message {
+			return record
+		}
+	}
+	t.Fatalf("No %q record in:\n%s", message, l.buffer.String())
+	return nil
+}
+
+func TestStructuredLogging(t *testing.T) {
+	t.Run("Records carry fields and the server name", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.CallToolResponse{Content: []mcp.Content{{Type: "text", Text: "ok"}}}, nil
+		})
+
+		capture := &logCapture{}
+		c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+			config.Logger = capture.logger()
+		})
+
+		if _, err := c.CallTool(context.Background(), "echo", nil); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+
+		initialized := capture.find(t, "MCP protocol initialized")
+		if initialized["level"] != "INFO" || initialized["server"] != "mock-server" || initialized["protocol_version"] != mcp.Version {
+			t.Errorf("Unexpected initialize record: %v", initialized)
+		}
+
+		calling := capture.find(t, "Calling tool")
+		if calling["level"] != "DEBUG" || calling["tool"] != "echo" || calling["server"] != "mock-server" {
+			t.Errorf("Unexpected tool record: %v", calling)
+		}
+	})
+
+	t.Run("Timeouts are warnings with the request", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			time.Sleep(time.Second)
+			return mcp.CallToolResponse{}, nil
+		})
+
+		capture := &logCapture{}
+		c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+			config.Logger = capture.logger()
+			config.Timeout = 50 * time.Millisecond
+		})
+
+		if _, err := c.CallTool(context.Background(), "slow", nil); err == nil {
+			t.Fatal("Expected CallTool to time out")
+		}
+
+		timedOut := capture.find(t, "Request timed out")
+		if timedOut["level"] != "WARN" || timedOut["method"] != "tools/call" || timedOut["request_id"] == nil {
+			t.Errorf("Unexpected timeout record: %v", timedOut)
+		}
+	})
+}
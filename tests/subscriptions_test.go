@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+// withServerCapabilities makes the mock server advertise caps on initialize
+func withServerCapabilities(m *mockTransport, caps mcp.ServerCapabilities) {
+	m.handle("initialize", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		return mcp.InitializeResponse{
+			ProtocolVersion: mcp.Version,
+			Capabilities:    caps,
+			ServerInfo:      mcp.ServerInfo{Name: "mock-server", Version: "1.0.0"},
+		}, nil
+	})
+}
+
+func TestResourceSubscriptions(t *testing.T) {
+	t.Run("Requires subscribe capability", func(t *testing.T) {
+		m := newMockTransport()
+		c := newTestClient(t, m)
+
+		err := c.SubscribeResource(context.Background(), "file:///a", func(mcp.ResourceUpdatedNotification) {})
+		if !errors.Is(err, client.ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %v", err)
+		}
+	})
+
+	t.Run("Updates are delivered and restored after reconnect", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{
+			Resources: &mcp.ResourcesCapability{Subscribe: true},
+		})
+		subscribes := make(chan string, 10)
+		m.handle("resources/subscribe", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var req mcp.SubscribeRequest
+			json.Unmarshal(params, &req)
+			subscribes <- req.URI
+			return struct{}{}, nil
+		})
+		c := newTestClient(t, m)
+
+		updates := make(chan string, 10)
+		ctx := context.Background()
+		err := c.SubscribeResource(ctx, "file:///a", func(n mcp.ResourceUpdatedNotification) {
+			updates <- n.URI
+		})
+		if err != nil {
+			t.Fatalf("SubscribeResource failed: %v", err)
+		}
+		<-subscribes
+
+		m.push(mcp.NewNotification(mcp.NotificationResourcesUpdated, mcp.ResourceUpdatedNotification{URI: "file:///a"}))
+		select {
+		case uri := <-updates:
+			if uri != "file:///a" {
+				t.Errorf("Unexpected update for %s", uri)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Update callback not called")
+		}
+
+		// Start a new session and check the subscription is re-sent
+		c.Disconnect()
+		if err := c.Connect(ctx); err != nil {
+			t.Fatalf("Reconnect failed: %v", err)
+		}
+		if err := c.Initialize(ctx, mcp.ClientInfo{Name: "test-client", Version: "1.0.0"}); err != nil {
+			t.Fatalf("Re-initialize failed: %v", err)
+		}
+
+		select {
+		case uri := <-subscribes:
+			if uri != "file:///a" {
+				t.Errorf("Unexpected re-subscription for %s", uri)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Subscription was not restored after reconnect")
+		}
+	})
+
+	t.Run("Updates sent with the acknowledgement are delivered", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{
+			Resources: &mcp.ResourcesCapability{Subscribe: true},
+		})
+		m.handle("resources/subscribe", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var req mcp.SubscribeRequest
+			json.Unmarshal(params, &req)
+			m.push(mcp.NewNotification(mcp.NotificationResourcesUpdated, mcp.ResourceUpdatedNotification{URI: req.URI}))
+			// Let the client handle the update before the acknowledgement
+			time.Sleep(50 * time.Millisecond)
+			return struct{}{}, nil
+		})
+		c := newTestClient(t, m)
+
+		updates := make(chan string, 10)
+		err := c.SubscribeResource(context.Background(), "file:///a", func(n mcp.ResourceUpdatedNotification) {
+			updates <- n.URI
+		})
+		if err != nil {
+			t.Fatalf("SubscribeResource failed: %v", err)
+		}
+
+		select {
+		case <-updates:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Update sent before the acknowledgement was lost")
+		}
+	})
+
+	t.Run("Failed subscriptions are not kept", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{
+			Resources: &mcp.ResourcesCapability{Subscribe: true},
+		})
+		m.handle("resources/subscribe", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidParams, Message: "no such resource"}
+		})
+		c := newTestClient(t, m)
+
+		if err := c.SubscribeResource(context.Background(), "file:///missing", func(mcp.ResourceUpdatedNotification) {}); err == nil {
+			t.Fatal("Expected SubscribeResource to fail")
+		}
+		if uris := c.Subscriptions(); len(uris) != 0 {
+			t.Errorf("Expected no subscriptions, got %v", uris)
+		}
+	})
+}
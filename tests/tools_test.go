@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+type forecast struct {
+	City        string  `json:"city"`
+	Temperature float64 `json:"temperature"`
+}
+
+func TestCallToolInto(t *testing.T) {
+	m := newMockTransport()
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.CallToolRequest
+		json.Unmarshal(params, &req)
+		switch req.Name {
+		case "structured":
+			return mcp.CallToolResponse{
+				Content:           []mcp.Content{mcp.NewTextContent(`{"city":"Paris","temperature":21.5}`)},
+				StructuredContent: map[string]interface{}{"city": "Paris", "temperature": 21.5},
+			}, nil
+		case "json-text":
+			return mcp.CallToolResponse{
+				Content: []mcp.Content{mcp.NewTextContent(`{"city":"Oslo","temperature":-3}`)},
+			}, nil
+		case "plain-text":
+			return mcp.CallToolResponse{Content: []mcp.Content{mcp.NewTextContent("sunny")}}, nil
+		default:
+			return mcp.CallToolResponse{
+				Content: []mcp.Content{mcp.NewTextContent("unknown city")},
+				IsError: true,
+			}, nil
+		}
+	})
+	c := newTestClient(t, m)
+	ctx := context.Background()
+
+	t.Run("Structured content", func(t *testing.T) {
+		var out forecast
+		result, err := c.CallToolInto(ctx, "structured", nil, &out)
+		if err != nil {
+			t.Fatalf("CallToolInto failed: %v", err)
+		}
+		if out.City != "Paris" || out.Temperature != 21.5 {
+			t.Errorf("Unexpected result: %+v", out)
+		}
+		if result.StructuredContent == nil {
+			t.Error("Expected raw response to keep structured content")
+		}
+	})
+
+	t.Run("Falls back to JSON text", func(t *testing.T) {
+		var out forecast
+		if _, err := c.CallToolInto(ctx, "json-text", nil, &out); err != nil {
+			t.Fatalf("CallToolInto failed: %v", err)
+		}
+		if out.City != "Oslo" || out.Temperature != -3 {
+			t.Errorf("Unexpected result: %+v", out)
+		}
+	})
+
+	t.Run("No structured content", func(t *testing.T) {
+		var out forecast
+		_, err := c.CallToolInto(ctx, "plain-text", nil, &out)
+		if !errors.Is(err, client.ErrNoStructuredContent) {
+			t.Errorf("Expected ErrNoStructuredContent, got %v", err)
+		}
+	})
+
+	t.Run("Tool error", func(t *testing.T) {
+		var out forecast
+		result, err := c.CallToolInto(ctx, "failing", nil, &out)
+		if !errors.Is(err, client.ErrToolError) {
+			t.Errorf("Expected ErrToolError, got %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected the failed result to be returned")
+		}
+	})
+}
+
+func TestToolAnnotations(t *testing.T) {
+	var tool mcp.Tool
+	data := `{
+		"name": "delete_file",
+		"title": "Delete File",
+		"inputSchema": {"type": "object"},
+		"outputSchema": {"type": "object", "properties": {"deleted": {"type": "boolean"}}},
+		"annotations": {"idempotentHint": true, "openWorldHint": false}
+	}`
+	if err := json.Unmarshal([]byte(data), &tool); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if tool.DisplayName() != "Delete File" {
+		t.Errorf("Expected title, got %s", tool.DisplayName())
+	}
+	if tool.OutputSchema == nil {
+		t.Error("Expected output schema")
+	}
+
+	a := tool.Annotations
+	if a.IsReadOnly() || !a.IsDestructive() || !a.IsIdempotent() || a.IsOpenWorld() {
+		t.Errorf("Unexpected hints: readOnly=%v destructive=%v idempotent=%v openWorld=%v",
+			a.IsReadOnly(), a.IsDestructive(), a.IsIdempotent(), a.IsOpenWorld())
+	}
+
+	// Unannotated tools use the specification defaults
+	var none *mcp.ToolAnnotations
+	if none.IsReadOnly() || !none.IsDestructive() || none.IsIdempotent() || !none.IsOpenWorld() {
+		t.Error("Unexpected defaults for missing annotations")
+	}
+
+	readOnly := true
+	if (&mcp.ToolAnnotations{ReadOnlyHint: &readOnly}).IsDestructive() {
+		t.Error("Read-only tools must not be destructive")
+	}
+}
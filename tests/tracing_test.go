@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTracedClient returns a client recording its spans in the returned exporter
+func newTracedClient(t *testing.T, m *mockTransport) (*client.Client, *tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+		config.TracerProvider = provider
+	})
+	return c, exporter, provider
+}
+
+// spanNamed returns the last recorded span with the given name
+func spanNamed(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	spans := exporter.GetSpans()
+	for i := len(spans) - 1; i >= 0; i-- {
+		if spans[i].Name == name {
+			return spans[i]
+		}
+	}
+	t.Fatalf("No %s span among %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+// spanAttribute returns the value of an attribute of a span
+func spanAttribute(span tracetest.SpanStub, key string) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracing(t *testing.T) {
+	t.Run("Spans propagate through _meta", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.CallToolResponse{Content: []mcp.Content{{Type: "text", Text: "ok"}}}, nil
+		})
+		c, exporter, provider := newTracedClient(t, m)
+
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		if _, err := c.CallTool(ctx, "echo", nil); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		parent.End()
+
+		span := spanNamed(t, exporter, "tools/call")
+		if span.SpanKind != trace.SpanKindClient || span.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected a client span under the parent, got kind %v parent %v", span.SpanKind, span.Parent.SpanID())
+		}
+		for key, expected := range map[string]string{
+			"rpc.system":      "jsonrpc",
+			"rpc.method":      "tools/call",
+			"mcp.tool.name":   "echo",
+			"mcp.server.name": "mock-server",
+		} {
+			if value, _ := spanAttribute(span, key); value.AsString() != expected {
+				t.Errorf("Expected %s=%q, got %q", key, expected, value.AsString())
+			}
+		}
+
+		sent := m.waitForSent(t, func(message *mcp.Message) bool { return message.Method == "tools/call" })
+		meta := sent.Params.(map[string]interface{})["_meta"].(map[string]interface{})
+		expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+		if meta["traceparent"] != expected {
+			t.Errorf("Expected traceparent %s, got %v", expected, meta["traceparent"])
+		}
+
+		initialize := spanNamed(t, exporter, "initialize")
+		if _, ok := spanAttribute(initialize, "mcp.server.name"); ok {
+			t.Error("Server name should not be known before initialization")
+		}
+	})
+
+	t.Run("Errors set the status and code", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return nil, &mcp.ErrorInfo{Code: mcp.ErrorCodeInvalidTool, Message: "unknown tool"}
+		})
+		c, exporter, _ := newTracedClient(t, m)
+
+		if _, err := c.CallTool(context.Background(), "missing", nil); err == nil {
+			t.Fatal("Expected CallTool to fail")
+		}
+
+		span := spanNamed(t, exporter, "tools/call")
+		if span.Status.Code != codes.Error || span.Status.Description != "unknown tool" {
+			t.Errorf("Unexpected status: %+v", span.Status)
+		}
+		if code, _ := spanAttribute(span, "rpc.jsonrpc.error_code"); code.AsInt64() != mcp.ErrorCodeInvalidTool {
+			t.Errorf("Expected error code %d, got %d", mcp.ErrorCodeInvalidTool, code.AsInt64())
+		}
+	})
+
+	t.Run("Notifications become span events", func(t *testing.T) {
+		m := newMockTransport()
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			var request mcp.CallToolRequest
+			json.Unmarshal(params, &request)
+			m.push(mcp.NewNotification(mcp.NotificationProgress, mcp.ProgressNotification{
+				ProgressToken: request.Meta.ProgressToken,
+				Progress:      1,
+				Total:         2,
+			}))
+			m.push(mcp.NewNotification(mcp.NotificationMessage, mcp.LoggingMessageNotification{
+				Level: mcp.LoggingLevelInfo,
+				Data:  "working",
+			}))
+			return mcp.CallToolResponse{}, nil
+		})
+		c, exporter, _ := newTracedClient(t, m)
+
+		if _, err := c.CallToolWithProgress(context.Background(), "slow", nil, func(mcp.ProgressNotification) {}); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+
+		span := spanNamed(t, exporter, "tools/call")
+		if len(span.Events) != 2 || span.Events[0].Name != mcp.NotificationProgress || span.Events[1].Name != mcp.NotificationMessage {
+			t.Fatalf("Unexpected events: %+v", span.Events)
+		}
+		if progress := span.Events[0].Attributes; len(progress) == 0 || progress[0].Value.AsFloat64() != 1 {
+			t.Errorf("Unexpected progress attributes: %v", progress)
+		}
+	})
+
+	t.Run("Server requests continue the server's trace", func(t *testing.T) {
+		m := newMockTransport()
+		_, exporter, _ := newTracedClient(t, m)
+
+		traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		m.push(mcp.NewRequest("ping-1", "ping", map[string]interface{}{
+			"_meta": map[string]interface{}{"traceparent": traceparent},
+		}))
+		m.waitForSent(t, responseTo("ping-1"))
+
+		span := spanNamed(t, exporter, "ping")
+		if span.SpanKind != trace.SpanKindServer {
+			t.Errorf("Expected a server span, got %v", span.SpanKind)
+		}
+		if span.Parent.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent.SpanID().String() != "00f067aa0ba902b7" {
+			t.Errorf("Expected span to continue the server's trace, got parent %v", span.Parent)
+		}
+	})
+}
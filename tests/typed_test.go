@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+type forecastArgs struct {
+	City  string `json:"city"`
+	Days  int    `json:"days,omitempty"`
+	Units string `json:"-"`
+}
+
+func TestCall(t *testing.T) {
+	m := newMockTransport()
+	var received map[string]interface{}
+	m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.CallToolRequest
+		json.Unmarshal(params, &req)
+		received = req.Arguments
+		if req.Name == "failing" {
+			return mcp.CallToolResponse{Content: []mcp.Content{mcp.NewTextContent("unknown city")}, IsError: true}, nil
+		}
+		return mcp.CallToolResponse{
+			Content: []mcp.Content{mcp.NewTextContent(`{"city":"Oslo","temperature":-3}`)},
+		}, nil
+	})
+	c := newTestClient(t, m)
+	ctx := context.Background()
+
+	t.Run("Struct arguments use json tags", func(t *testing.T) {
+		out, err := client.Call[forecast](ctx, c, "weather", forecastArgs{City: "Oslo", Units: "metric"})
+		if err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+		if out.City != "Oslo" || out.Temperature != -3 {
+			t.Errorf("Unexpected result: %+v", out)
+		}
+		if len(received) != 1 || received["city"] != "Oslo" {
+			t.Errorf("Unexpected arguments: %v", received)
+		}
+	})
+
+	t.Run("Pointer results", func(t *testing.T) {
+		out, err := client.Call[*forecast](ctx, c, "weather", &forecastArgs{City: "Oslo", Days: 2})
+		if err != nil || out == nil || out.City != "Oslo" {
+			t.Fatalf("Unexpected result: %+v, %v", out, err)
+		}
+		if received["days"] != float64(2) {
+			t.Errorf("Unexpected arguments: %v", received)
+		}
+	})
+
+	t.Run("Arguments must be an object", func(t *testing.T) {
+		if _, err := client.Call[forecast](ctx, c, "weather", []string{"Oslo"}); err == nil {
+			t.Error("Expected array arguments to fail")
+		}
+	})
+
+	t.Run("Tool error", func(t *testing.T) {
+		out, err := client.Call[forecast](ctx, c, "failing", nil)
+		if !errors.Is(err, client.ErrToolError) {
+			t.Errorf("Expected ErrToolError, got %v", err)
+		}
+		if out != (forecast{}) {
+			t.Errorf("Expected the zero value on error, got %+v", out)
+		}
+	})
+}
+
+func TestReadResourceAs(t *testing.T) {
+	m := newMockTransport()
+	m.handle("resources/read", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+		var req mcp.ReadResourceRequest
+		json.Unmarshal(params, &req)
+		contents := mcp.ResourceContents{URI: req.URI}
+		switch req.URI {
+		case "weather://text":
+			contents.MimeType = "application/json; charset=utf-8"
+			contents.Text = `{"city":"Paris","temperature":21.5}`
+		case "weather://blob":
+			contents.MimeType = "application/geo+json"
+			contents.Blob = base64.StdEncoding.EncodeToString([]byte(`{"city":"Rome","temperature":30}`))
+		case "weather://plain":
+			contents.MimeType = "text/plain"
+			contents.Text = "sunny"
+		default:
+			return mcp.ReadResourceResponse{}, nil
+		}
+		return mcp.ReadResourceResponse{Contents: []mcp.ResourceContents{contents}}, nil
+	})
+	c := newTestClient(t, m)
+	ctx := context.Background()
+
+	out, err := client.ReadResourceAs[forecast](ctx, c, "weather://text")
+	if err != nil || out.City != "Paris" || out.Temperature != 21.5 {
+		t.Errorf("Unexpected text resource: %+v, %v", out, err)
+	}
+
+	out, err = client.ReadResourceAs[forecast](ctx, c, "weather://blob")
+	if err != nil || out.City != "Rome" {
+		t.Errorf("Unexpected blob resource: %+v, %v", out, err)
+	}
+
+	if _, err := client.ReadResourceAs[forecast](ctx, c, "weather://plain"); !errors.Is(err, client.ErrNotJSON) {
+		t.Errorf("Expected ErrNotJSON, got %v", err)
+	}
+
+	if _, err := client.ReadResourceAs[forecast](ctx, c, "weather://empty"); err == nil {
+		t.Error("Expected a resource without contents to fail")
+	}
+}
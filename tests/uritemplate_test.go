@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/uritemplate"
+)
+
+func TestURITemplateExpand(t *testing.T) {
+	// Variables and expectations from RFC 6570 section 3.2, with map keys in
+	// sorted order
+	vars := map[string]interface{}{
+		"var":   "value",
+		"hello": "Hello World!",
+		"path":  "/foo/bar",
+		"list":  []string{"red", "green", "blue"},
+		"keys":  map[string]string{"semi": ";", "dot": ".", "comma": ","},
+		"x":     1024,
+		"y":     768,
+		"empty": "",
+	}
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"{var}", "value"},
+		{"{hello}", "Hello%20World%21"},
+		{"{+hello}", "Hello%20World!"},
+		{"{+path}/here", "/foo/bar/here"},
+		{"{#hello}", "#Hello%20World!"},
+		{"{var:3}", "val"},
+		{"{list}", "red,green,blue"},
+		{"{list*}", "red,green,blue"},
+		{"{keys}", "comma,%2C,dot,.,semi,%3B"},
+		{"{keys*}", "comma=%2C,dot=.,semi=%3B"},
+		{"{+keys}", "comma,,,dot,.,semi,;"},
+		{"{x,hello,y}", "1024,Hello%20World%21,768"},
+		{"{?x,y,empty}", "?x=1024&y=768&empty="},
+		{"{?x,y,undef}", "?x=1024&y=768"},
+		{"{.list}", ".red,green,blue"},
+		{"{.list*}", ".red.green.blue"},
+		{"{/var,x}/here", "/value/1024/here"},
+		{"{/list*,path:4}", "/red/green/blue/%2Ffoo"},
+		{"{;x,y,empty}", ";x=1024;y=768;empty"},
+		{"{;list*}", ";list=red;list=green;list=blue"},
+		{"{?keys*}", "?comma=%2C&dot=.&semi=%3B"},
+		{"?fixed=yes{&x}", "?fixed=yes&x=1024"},
+		{"{?var:3}", "?var=val"},
+		{"file:///{+path}", "file:////foo/bar"},
+		{"{undef}", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			got, err := uritemplate.Expand(tt.template, vars)
+			if err != nil {
+				t.Fatalf("Expand failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	for _, template := range []string{"{keys:1}", "{var", "{}", "{=var}", "{va r}"} {
+		if _, err := uritemplate.Expand(template, vars); err == nil {
+			t.Errorf("Expected error for %q", template)
+		}
+	}
+}
+
+func TestURITemplateVariables(t *testing.T) {
+	names, err := uritemplate.Variables("db://{schema}/tables/{table}{?limit,offset,schema}")
+	if err != nil {
+		t.Fatalf("Variables failed: %v", err)
+	}
+
+	expected := []string{"schema", "table", "limit", "offset"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
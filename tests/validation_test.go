@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/client"
+	"github.com/kunalkushwaha/mcp-navigator-go/pkg/mcp"
+)
+
+func TestToolArgumentValidation(t *testing.T) {
+	searchTool := mcp.Tool{
+		Name: "search",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{"type": "string"},
+				"limit": map[string]interface{}{"type": "integer", "minimum": 1},
+			},
+			"required": []string{"query"},
+		},
+	}
+
+	// newServer returns a mock serving searchTool and counting tools/call
+	newServer := func() (*mockTransport, *int32) {
+		m := newMockTransport()
+		newToolServer(m, searchTool)
+		var calls int32
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			atomic.AddInt32(&calls, 1)
+			return mcp.CallToolResponse{Content: []mcp.Content{mcp.NewTextContent("ok")}}, nil
+		})
+		return m, &calls
+	}
+	validate := func(config *client.ClientConfig) { config.ValidateArguments = true }
+	ctx := context.Background()
+
+	t.Run("Invalid arguments are not sent", func(t *testing.T) {
+		m, calls := newServer()
+		c := newConfiguredClient(t, m, validate)
+
+		_, err := c.CallTool(ctx, "search", map[string]interface{}{"limit": "ten"})
+
+		var invalid *client.ValidationError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected *ValidationError, got %v", err)
+		}
+		if invalid.Tool != "search" || len(invalid.Violations) != 2 {
+			t.Fatalf("Unexpected validation error: %+v", invalid)
+		}
+		if invalid.Violations[0].Path != "query" || invalid.Violations[1].Path != "limit" {
+			t.Errorf("Unexpected violation paths: %v", invalid.Violations)
+		}
+		if got := atomic.LoadInt32(calls); got != 0 {
+			t.Errorf("Expected no tools/call, got %d", got)
+		}
+	})
+
+	t.Run("Valid arguments are sent", func(t *testing.T) {
+		m, calls := newServer()
+		c := newConfiguredClient(t, m, validate)
+
+		if _, err := c.CallTool(ctx, "search", map[string]interface{}{"query": "go", "limit": 5}); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		if got := atomic.LoadInt32(calls); got != 1 {
+			t.Errorf("Expected 1 tools/call, got %d", got)
+		}
+	})
+
+	t.Run("Unknown tool", func(t *testing.T) {
+		m, _ := newServer()
+		c := newConfiguredClient(t, m, validate)
+
+		_, err := c.CallTool(ctx, "missing", nil)
+		if !errors.Is(err, client.ErrUnknownTool) {
+			t.Errorf("Expected ErrUnknownTool, got %v", err)
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		m, calls := newServer()
+		c := newTestClient(t, m)
+
+		if _, err := c.CallTool(ctx, "search", map[string]interface{}{"limit": "ten"}); err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		if got := atomic.LoadInt32(calls); got != 1 {
+			t.Errorf("Expected 1 tools/call, got %d", got)
+		}
+	})
+
+	t.Run("ValidateToolArguments refreshes after list_changed", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{Tools: &mcp.ToolsCapability{ListChanged: true}})
+		server := newToolServer(m, searchTool)
+		c := newTestClient(t, m)
+
+		if err := c.ValidateToolArguments(ctx, "search", map[string]interface{}{"query": "go"}); err != nil {
+			t.Fatalf("Expected valid arguments, got %v", err)
+		}
+
+		// The server now requires a limit as well
+		updated := searchTool
+		updated.InputSchema = map[string]interface{}{
+			"type":     "object",
+			"required": []string{"query", "limit"},
+		}
+		server.setTools(updated)
+		m.push(mcp.NewNotification(mcp.NotificationToolsListChanged, nil))
+
+		var invalid *client.ValidationError
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			err := c.ValidateToolArguments(ctx, "search", map[string]interface{}{"query": "go"})
+			if errors.As(err, &invalid) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if invalid == nil || invalid.Violations[0].Path != "limit" {
+			t.Errorf("Expected the new schema to be used, got %+v", invalid)
+		}
+	})
+
+	t.Run("Tools added without list_changed are found", func(t *testing.T) {
+		m := newMockTransport()
+		withServerCapabilities(m, mcp.ServerCapabilities{Tools: &mcp.ToolsCapability{ListChanged: true}})
+		server := newToolServer(m, searchTool)
+		m.handle("tools/call", func(params json.RawMessage) (interface{}, *mcp.ErrorInfo) {
+			return mcp.CallToolResponse{Content: []mcp.Content{mcp.NewTextContent("ok")}}, nil
+		})
+		c := newConfiguredClient(t, m, func(config *client.ClientConfig) {
+			config.ValidateArguments = true
+			config.CacheCatalog = true
+		})
+
+		if _, err := c.ListTools(ctx); err != nil {
+			t.Fatalf("ListTools failed: %v", err)
+		}
+
+		// The server adds a tool but never says so
+		server.setTools(searchTool, mcp.Tool{Name: "echo", InputSchema: map[string]interface{}{"type": "object"}})
+
+		if _, err := c.CallTool(ctx, "echo", nil); err != nil {
+			t.Errorf("Expected the new tool to be found, got %v", err)
+		}
+		if _, err := c.CallTool(ctx, "missing", nil); !errors.Is(err, client.ErrUnknownTool) {
+			t.Errorf("Expected ErrUnknownTool, got %v", err)
+		}
+	})
+}